@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/harshul/octo-cli/internal/analyzer"
 	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/config"
 	"github.com/harshul/octo-cli/internal/doctor"
 	"github.com/harshul/octo-cli/internal/provisioner"
 	"github.com/harshul/octo-cli/internal/secrets"
+	"github.com/harshul/octo-cli/internal/shellquote"
 	"github.com/harshul/octo-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -39,6 +44,13 @@ func init() {
 	initCmd.Flags().Bool("auto-install", false, "Automatically install dependencies without prompting")
 	initCmd.Flags().Bool("skip-secrets", false, "Skip secrets/environment variable setup")
 	initCmd.Flags().StringP("env", "e", "development", "Target environment (development, production) - affects script selection")
+	initCmd.Flags().Bool("check", false, "Exit 0 if the existing configuration matches the project, 1 (with a diff on stderr) if it is stale")
+	initCmd.Flags().Bool("dry-run", false, "Preview the configuration, dependency install and .env changes without writing anything")
+	initCmd.Flags().Bool("auto", false, "Refresh the detected fields of the configuration without prompting, only writing it if something changed")
+	initCmd.Flags().Bool("install-hooks", false, "Install post-checkout and post-merge git hooks that run 'octo init --auto' when dependency files change")
+	initCmd.Flags().Bool("remove-hooks", false, "Remove the git hooks added by --install-hooks")
+	initCmd.Flags().Bool("generate-makefile", false, "Also write a Makefile with run, init, stop and ps targets that call octo")
+	initCmd.Flags().String("template", "", fmt.Sprintf("Generate configuration from a project template (%s)", strings.Join(blueprint.TemplateNames(), ", ")))
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -48,6 +60,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	// ~/.octo/config.yaml supplies defaults for flags not given on the command line
+	globalConfig, err := config.Load()
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not read global configuration: %v", err))
+	}
+	if err := applyGlobalFlags(cmd, globalConfig.InitFlags()); err != nil {
+		return err
+	}
+
 	// Get flag values
 	outputPath, _ := cmd.Flags().GetString("output")
 	force, _ := cmd.Flags().GetBool("force")
@@ -56,17 +77,53 @@ func runInit(cmd *cobra.Command, args []string) error {
 	autoInstall, _ := cmd.Flags().GetBool("auto-install")
 	skipSecrets, _ := cmd.Flags().GetBool("skip-secrets")
 	env, _ := cmd.Flags().GetString("env")
+	template, _ := cmd.Flags().GetString("template")
+	check, _ := cmd.Flags().GetBool("check")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	auto, _ := cmd.Flags().GetBool("auto")
+	installHooks, _ := cmd.Flags().GetBool("install-hooks")
+	removeHooks, _ := cmd.Flags().GetBool("remove-hooks")
+	generateMakefile, _ := cmd.Flags().GetBool("generate-makefile")
+
+	if installHooks && removeHooks {
+		return fmt.Errorf("--install-hooks and --remove-hooks cannot be used together")
+	}
+	if removeHooks {
+		return removeInitHooks(cwd, dryRun)
+	}
+	if installHooks {
+		outputFlag := ""
+		if cmd.Flags().Changed("output") {
+			outputFlag = " --output " + shellquote.Quote(outputPath)
+		}
+		return installInitHooks(cwd, outputFlag, dryRun)
+	}
 
 	// Resolve output path
 	if !filepath.IsAbs(outputPath) {
 		outputPath = filepath.Join(cwd, outputPath)
 	}
 
+	// --check compares instead of writing, e.g. in a pre-commit hook
+	if check {
+		return runInitCheck(cwd, outputPath, env, skipSecrets)
+	}
+
+	// --auto refreshes the configuration silently, e.g. from the hooks added by --install-hooks
+	if auto {
+		return runInitAuto(cwd, outputPath, env, skipSecrets)
+	}
+
 	// Check if file already exists
-	if _, err := os.Stat(outputPath); err == nil && !force {
+	if _, err := os.Stat(outputPath); err == nil && !force && !dryRun {
 		return fmt.Errorf("configuration file already exists at %s. Use --force to overwrite", outputPath)
 	}
 
+	// Templates bootstrap a configuration without analyzing existing code
+	if template != "" {
+		return runInitFromTemplate(template, analyzer.InferProjectName(cwd), outputPath, dryRun)
+	}
+
 	// ========================================
 	// Show intro animation
 	// ========================================
@@ -97,18 +154,36 @@ func runInit(cmd *cobra.Command, args []string) error {
 	ui.PrintSuccess("Analysis complete")
 	fmt.Println()
 
+	// A Dockerfile's EXPOSE declares the port the app is meant to listen on
+	if exposed := blueprint.DetectExposedPorts(cwd); len(exposed) > 0 {
+		if port, err := selectExposedPort(exposed); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Could not select a port: %v", err))
+		} else {
+			projectInfo = applyExposedPort(projectInfo, port)
+		}
+	}
+
 	// Display detected project information with nice formatting
 	ui.PrintDivider()
 	ui.PrintHighlight("Language", projectInfo.Language)
+	if projectInfo.Framework != "" {
+		ui.PrintHighlight("Framework", projectInfo.Framework)
+	}
 	if projectInfo.PackageManager != "" {
 		ui.PrintHighlight("Package Manager", projectInfo.PackageManager)
 	}
+	if len(projectInfo.WorkspacePackages) > 0 {
+		ui.PrintHighlight("Packages", fmt.Sprintf("%d (%s)", len(projectInfo.WorkspacePackages), strings.Join(projectInfo.WorkspacePackages, ", ")))
+	}
 	if projectInfo.Version != "" {
 		ui.PrintHighlight("Version", projectInfo.Version)
 	}
 	if projectInfo.RunCommand != "" {
 		ui.PrintHighlight("Run Command", projectInfo.RunCommand)
 	}
+	if projectInfo.PortConfig.FlagType == "dockerfile-expose" {
+		ui.PrintHighlight("Port", fmt.Sprintf("%d (Dockerfile EXPOSE)", projectInfo.PortConfig.Port))
+	}
 	ui.PrintDivider()
 	fmt.Println()
 
@@ -136,7 +211,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// ========================================
 	// STEP 4: Prompt for Provisioning
 	// ========================================
-	if !skipInstall && diagnosis.Dependencies.ConfigFile != "" && !diagnosis.Dependencies.Installed {
+	needsInstall := diagnosis.Dependencies.ConfigFile != "" && !diagnosis.Dependencies.Installed
+	if dryRun && !skipInstall && needsInstall {
+		ui.PrintInfo(fmt.Sprintf("Dry run: would offer to install dependencies with `%s`", diagnosis.Dependencies.InstallCommand))
+	} else if !skipInstall && needsInstall {
 		// Check if package manager needs to be installed first
 		if !diagnosis.Dependencies.ManagerInstalled {
 			pmInfo := provisioner.DetectPackageManager(cwd)
@@ -173,6 +251,45 @@ func runInit(cmd *cobra.Command, args []string) error {
 								ui.PrintSuccess("All dependencies verified")
 							} else {
 								ui.PrintWarning("Some dependencies may need attention")
+								for _, issue := range newDiagnosis.Issues {
+									ui.PrintInfo(issue)
+								}
+							}
+						}
+					}
+				}
+			} else if diagnosis.Dependencies.Manager == "bundler" || diagnosis.Dependencies.Manager == "poetry" {
+				// Ruby and Poetry projects: offer to install the package manager natively
+				var pmResult provisioner.EnsureManagerResult
+				if diagnosis.Dependencies.Manager == "bundler" {
+					pmResult = provisioner.EnsureBundler(nil)
+				} else {
+					pmResult = provisioner.EnsurePoetry(nil)
+				}
+
+				if !pmResult.Available {
+					ui.PrintError(pmResult.UserMessage)
+					ui.PrintWarning("Skipping dependency installation.")
+				} else {
+					if pmResult.UserMessage != "" {
+						ui.PrintSuccess(pmResult.UserMessage)
+					}
+
+					ui.PrintStep(3, 5, fmt.Sprintf("Installing dependencies (%s)...", diagnosis.Dependencies.InstallCommand))
+					err := doctor.InstallDependencies(cwd, diagnosis.Dependencies.InstallCommand)
+
+					if err != nil {
+						ui.PrintError(fmt.Sprintf("Installation failed: %v", err))
+					} else {
+						ui.PrintSuccess("Dependencies installed")
+						ui.PrintStep(4, 5, "Verifying installation...")
+						newDiagnosis := doctor.VerifyInstallation(cwd, projectInfo.Language)
+						if newDiagnosis.Dependencies.Installed {
+							ui.PrintSuccess("All dependencies verified")
+						} else {
+							ui.PrintWarning("Some dependencies may need attention")
+							for _, issue := range newDiagnosis.Issues {
+								ui.PrintInfo(issue)
 							}
 						}
 					}
@@ -206,6 +323,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 							ui.PrintSuccess("All dependencies verified")
 						} else {
 							ui.PrintWarning("Some dependencies may need attention")
+							for _, issue := range newDiagnosis.Issues {
+								ui.PrintInfo(issue)
+							}
 						}
 					}
 				}
@@ -249,6 +369,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 						ui.PrintSuccess("All dependencies verified")
 					} else {
 						ui.PrintWarning("Some dependencies may need attention")
+						for _, issue := range newDiagnosis.Issues {
+							ui.PrintInfo(issue)
+						}
 					}
 				}
 			} else {
@@ -302,7 +425,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 				}
 			}
 			
-			if len(envStatus.Missing) > 0 {
+			if dryRun && len(envStatus.Missing) > 0 {
+				previewEnvProvisioning(cwd, projectInfo.Language)
+			} else if len(envStatus.Missing) > 0 {
 				// Build vars with defaults for enhanced prompt
 				varsWithDefaults := make([]ui.EnvVarWithDefault, 0, len(envStatus.Missing))
 				for _, v := range envStatus.Missing {
@@ -313,7 +438,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 						TargetDir:   v.TargetDir,
 					}
 					
-					// Try to get suggestion if no default from README
+					// README and example defaults win; fall back to heuristics
 					if vwd.Default == "" {
 						vwd.Default = secrets.GetEnvVarSuggestion(v.Name, envStatus.ReadmeDefaults)
 					}
@@ -321,6 +446,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 					varsWithDefaults = append(varsWithDefaults, vwd)
 				}
 
+				// Show where each variable was discovered so suggested values can be trusted
+				fmt.Println()
+				ui.PrintInfo("Variables to configure:")
+				for _, v := range envStatus.Missing {
+					fmt.Printf("    • %s (from %s)\n", v.Name, v.Source)
+				}
+
 				// Ask if user wants to set them up with Vite-style prompt
 				fmt.Println()
 				shouldSetup := promptForSecretsVite(len(envStatus.Missing))
@@ -377,21 +509,58 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Generate the blueprint from project info
 	bp := blueprint.FromProjectInfo(projectInfo)
 
+	// Pick the primary task runner when the repo configures several (e.g. Nx next to Turborepo)
+	if runner, err := selectWorkspaceRunner(projectInfo.WorkspaceRunners); err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not select a workspace runner: %v", err))
+	} else if runner != "" {
+		bp.WorkspaceRunner = runner
+		ui.PrintHighlight("Workspace Runner", runner)
+		if len(projectInfo.WorkspaceRunners) > 1 {
+			// The package script may start a different runner, so call the chosen one directly
+			bp.RunCommand = analyzer.WorkspaceRunCommand(runner, projectInfo.PackageManager, bp.RunCommand)
+			ui.PrintHighlight("Run Command", bp.RunCommand)
+		}
+	}
+
+	// Reuse the project's own Docker base image for container mode
+	if baseImage := blueprint.DetectBaseImage(cwd); baseImage != "" {
+		bp.BaseImage = baseImage
+		ui.PrintHighlight("Base Image", baseImage)
+
+		// Differences between the container and local runtime break CI parity.
+		// Kotlin images pin the JDK, not the Kotlin compiler.
+		localVersion := diagnosis.Runtime.Version
+		if bp.Language == "Kotlin" {
+			localVersion = doctor.JavaRuntime().Version
+		}
+		if warning := blueprint.BaseImageVersionWarning(baseImage, bp.Language, localVersion); warning != "" {
+			bp.Warning = warning
+			fmt.Println()
+			ui.PrintWarning(warning)
+			fmt.Println()
+		}
+	}
+
 	// Add detected environment variables to blueprint
 	if len(allDetectedVars) > 0 {
-		bp.EnvVars = make([]blueprint.EnvVar, len(allDetectedVars))
-		for i, v := range allDetectedVars {
-			bp.EnvVars[i] = blueprint.EnvVar{
-				Name:     v.Name,
-				Required: v.Required,
-			}
-		}
+		bp.EnvVars = envVarsFromSecrets(allDetectedVars)
+		// Categorize them too so larger projects stay manageable
+		bp.EnvVarGroups = blueprint.GroupEnvVars(bp.EnvVars)
 	}
 
+	// Offer local databases and caches for the connection strings the app reads
+	bp.HasDockerCompose = setupDockerCompose(cwd, bp, dryRun)
+
 	// ========================================
 	// STEP 5: Write Configuration
 	// ========================================
 	fmt.Println()
+	if dryRun {
+		if generateMakefile {
+			ui.PrintInfo("Dry run: would write a Makefile with run, init, stop and ps targets")
+		}
+		return printDryRunConfig(outputPath, bp)
+	}
 	ui.PrintStep(5, 5, "Writing configuration...")
 
 	// Write the configuration file
@@ -399,6 +568,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write configuration: %w", err)
 	}
 
+	if generateMakefile {
+		writeMakefile(cwd, outputPath, bp, force)
+	}
+
 	// Final success message
 	fmt.Println()
 	ui.PrintDivider()
@@ -411,6 +584,266 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// writeMakefile writes a Makefile wrapping the octo commands, leaving an existing one
+// alone unless --force is given
+func writeMakefile(cwd string, outputPath string, bp blueprint.Blueprint, force bool) {
+	makefilePath := filepath.Join(cwd, "Makefile")
+	if _, err := os.Stat(makefilePath); err == nil && !force {
+		ui.PrintWarning("Makefile already exists - not overwriting it (use --force to replace it)")
+		return
+	}
+
+	configPath, err := filepath.Rel(cwd, outputPath)
+	if err != nil {
+		configPath = outputPath
+	}
+	if err := blueprint.WriteMakefile(makefilePath, bp, configPath); err != nil {
+		ui.PrintError(err.Error())
+		return
+	}
+	ui.PrintSuccess("Makefile written - try make run, make stop or make init")
+}
+
+// setupDockerCompose offers to generate a docker-compose.yml for the databases and caches
+// behind detected connection env vars, and writes matching connection strings to .env.
+// It reports whether the project has a Compose file afterwards.
+func setupDockerCompose(cwd string, bp blueprint.Blueprint, dryRun bool) bool {
+	if blueprint.FindComposeFile(cwd) != "" {
+		return true
+	}
+
+	services := blueprint.ComposeServicesFor(bp.EnvVars, bp.Name)
+	if len(services) == 0 {
+		return false
+	}
+
+	var names []string
+	for _, s := range services {
+		names = append(names, fmt.Sprintf("%s (%s)", s.Name, s.EnvVar))
+	}
+
+	if dryRun {
+		ui.PrintInfo("Dry run: would offer a docker-compose.yml with " + strings.Join(names, ", "))
+		return false
+	}
+
+	fmt.Println()
+	generate, err := ui.RunYesNoPrompt("Generate docker-compose.yml?",
+		"Local services for: "+strings.Join(names, ", "), true)
+	if err != nil || !generate {
+		return false
+	}
+
+	composePath := filepath.Join(cwd, "docker-compose.yml")
+	if err := blueprint.WriteDockerCompose(composePath, services); err != nil {
+		ui.PrintError(err.Error())
+		return false
+	}
+	ui.PrintSuccess("Generated docker-compose.yml (start it with `docker compose up -d`)")
+
+	// Point the app at the new services unless it is already configured
+	existing := secrets.GetAllEnvVars(cwd)
+	values := make(map[string]string)
+	for _, s := range services {
+		if existing[s.EnvVar] == "" {
+			values[s.EnvVar] = s.ConnectionString
+		}
+	}
+	if len(values) > 0 {
+		if err := secrets.AppendToEnvFile(filepath.Join(cwd, ".env"), values); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to write connection strings to .env: %v", err))
+		} else {
+			ui.PrintSuccess(fmt.Sprintf("Added %d connection string(s) to .env", len(values)))
+			ensureGitignore(cwd)
+		}
+	}
+	return true
+}
+
+// previewEnvProvisioning shows which .env files and values auto-provisioning would write
+func previewEnvProvisioning(cwd string, language string) {
+	result, err := secrets.AutoProvisionEnvFiles(cwd, language, true)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not preview .env provisioning: %v", err))
+		return
+	}
+
+	for _, file := range result.CreatedFiles {
+		ui.PrintInfo("Dry run: would create " + file)
+	}
+
+	names := make([]string, 0, len(result.ProvisionedVars))
+	for name := range result.ProvisionedVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("    • %s=%s\n", name, maskEnvValue(result.ProvisionedVars[name]))
+	}
+
+	if len(result.SkippedVars) > 0 {
+		ui.PrintInfo(fmt.Sprintf("Dry run: %d variable(s) have no default and would need a value: %s",
+			len(result.SkippedVars), strings.Join(result.SkippedVars, ", ")))
+	}
+}
+
+// printDryRunConfig prints the configuration init would write to outputPath
+func printDryRunConfig(outputPath string, bp blueprint.Blueprint) error {
+	data, err := blueprint.Marshal(bp)
+	if err != nil {
+		return fmt.Errorf("failed to generate configuration: %w", err)
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Dry run: would write %s:", outputPath))
+	fmt.Println()
+	fmt.Print(string(data))
+	fmt.Println()
+	ui.PrintInfo("Dry run complete - nothing was written")
+	return nil
+}
+
+// envVarsFromSecrets converts scanned environment variables into blueprint entries
+func envVarsFromSecrets(vars []secrets.EnvVar) []blueprint.EnvVar {
+	if len(vars) == 0 {
+		return nil
+	}
+	envVars := make([]blueprint.EnvVar, len(vars))
+	for i, v := range vars {
+		envVars[i] = blueprint.EnvVar{
+			Name:     v.Name,
+			Required: v.Required,
+		}
+	}
+	return envVars
+}
+
+// selectWorkspaceRunner returns the monorepo task runner to store in the blueprint.
+// A single detected runner is used as-is; with several the user chooses one.
+func selectWorkspaceRunner(runners []string) (string, error) {
+	if len(runners) <= 1 {
+		if len(runners) == 1 {
+			return runners[0], nil
+		}
+		return "", nil
+	}
+
+	options := make([]ui.SelectOption, 0, len(runners))
+	for _, runner := range runners {
+		for _, cfg := range analyzer.WorkspaceRunnerConfigs {
+			if cfg.Name == runner {
+				options = append(options, ui.SelectOption{
+					Label:       runner,
+					Value:       runner,
+					Description: fmt.Sprintf("configured by %s", cfg.ConfigFile),
+				})
+			}
+		}
+	}
+
+	fmt.Println()
+	selected, err := ui.RunSelectPrompt(
+		"Multiple workspace runners detected",
+		"Which one should octo use as the primary runner?",
+		options,
+	)
+	if err != nil {
+		return "", err
+	}
+	if selected.Value == "" {
+		// Cancelled - fall back to the highest-priority runner
+		return runners[0], nil
+	}
+	return selected.Value, nil
+}
+
+// selectExposedPort returns the primary port among those exposed by the Dockerfile.
+// With several exposed ports the user chooses one; the first is used if the prompt is cancelled.
+func selectExposedPort(exposed []int) (int, error) {
+	if len(exposed) == 1 {
+		return exposed[0], nil
+	}
+
+	options := make([]ui.SelectOption, len(exposed))
+	for i, port := range exposed {
+		options[i] = ui.SelectOption{
+			Label:       strconv.Itoa(port),
+			Value:       strconv.Itoa(port),
+			Description: "declared by EXPOSE in Dockerfile",
+		}
+	}
+
+	selected, err := ui.RunSelectPrompt(
+		"Multiple exposed ports detected",
+		"Which port does the app listen on?",
+		options,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if selected.Value == "" {
+		return exposed[0], nil
+	}
+	return strconv.Atoi(selected.Value)
+}
+
+// applyExposedPort records the Dockerfile's port as the project's default port.
+// A port set explicitly in the run command (e.g. --port 4000) is left alone. The run command
+// itself is not changed: a flag appended here would be saved to .octo.yaml even where the
+// app doesn't accept it, so passing the port is left to run-time port handling.
+func applyExposedPort(info analyzer.ProjectInfo, port int) analyzer.ProjectInfo {
+	if info.PortConfig.Detected && !info.PortConfig.IsDefault {
+		return info
+	}
+
+	info.PortConfig = analyzer.PortConfig{
+		Port:      port,
+		Detected:  true,
+		FlagType:  "dockerfile-expose",
+		IsDefault: true,
+	}
+	return info
+}
+
+// runInitFromTemplate writes a configuration generated from a predefined template
+func runInitFromTemplate(template string, projectName string, outputPath string, dryRun bool) error {
+	bp, err := blueprint.FromTemplate(template, projectName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	ui.PrintHeader("🐙 Octo Init")
+	fmt.Println()
+
+	ui.PrintDivider()
+	ui.PrintHighlight("Template", template)
+	ui.PrintHighlight("Language", bp.Language)
+	ui.PrintHighlight("Setup Command", bp.SetupCommand)
+	ui.PrintHighlight("Run Command", bp.RunCommand)
+	if bp.HealthCheck != "" {
+		ui.PrintHighlight("Health Check", bp.HealthCheck)
+	}
+	ui.PrintDivider()
+
+	if dryRun {
+		fmt.Println()
+		return printDryRunConfig(outputPath, bp)
+	}
+
+	if err := blueprint.Write(outputPath, bp); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	fmt.Println()
+	ui.PrintSuccess(fmt.Sprintf("Configuration written to %s", outputPath))
+	fmt.Println()
+	ui.PrintInfo("Next steps:")
+	fmt.Println("    Run " + "\033[1mocto run\033[0m" + " to start your application")
+	fmt.Println()
+
+	return nil
+}
+
 // ============================================================================
 // Vite-style Helper Functions
 // ============================================================================
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/harshul/octo-cli/internal/analyzer"
+	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/secrets"
+)
+
+// generatedField is a configuration field octo init derives from the project.
+// Fields init never writes (services, health_check, thermal, ...) are left to the user and not compared.
+// Neither are run and setup: they are the commands users customise most, so a difference
+// from the detected command is an override rather than a sign the file is stale.
+type generatedField struct {
+	name  string
+	value func(bp blueprint.Blueprint) string
+}
+
+// generatedFields lists the fields compared by `octo init --check`
+var generatedFields = []generatedField{
+	{"language", func(bp blueprint.Blueprint) string { return bp.Language }},
+	{"version", func(bp blueprint.Blueprint) string { return bp.Version }},
+	{"setup_required", func(bp blueprint.Blueprint) string { return fmt.Sprint(bp.SetupRequired) }},
+	{"package_manager", func(bp blueprint.Blueprint) string { return bp.PackageManager }},
+	{"is_monorepo", func(bp blueprint.Blueprint) string { return fmt.Sprint(bp.IsMonorepo) }},
+	{"monorepo_root", func(bp blueprint.Blueprint) string { return bp.MonorepoRoot }},
+	{"workspace_runner", func(bp blueprint.Blueprint) string { return bp.WorkspaceRunner }},
+	{"base_image", func(bp blueprint.Blueprint) string { return bp.BaseImage }},
+	{"env_vars", func(bp blueprint.Blueprint) string { return envVarNames(bp.AllEnvVars()) }},
+}
+
+// runInitCheck re-analyzes the project and compares the result with the existing configuration.
+// It exits 0 if every generated field matches and 1, with a diff on stderr, if the configuration is stale.
+func runInitCheck(cwd string, configPath string, env string, skipSecrets bool) error {
+	existing, err := blueprint.Read(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	fresh, err := generateBlueprint(cwd, env, skipSecrets, existing)
+	if err != nil {
+		return err
+	}
+
+	var diff []string
+	for _, field := range generatedFields {
+		// Env vars are only scanned when secrets are not skipped
+		if field.name == "env_vars" && skipSecrets {
+			continue
+		}
+		have, want := field.value(existing), field.value(fresh)
+		if have != want {
+			diff = append(diff, fmt.Sprintf("  %s:\n    - %s\n    + %s", field.name, displayValue(have), displayValue(want)))
+		}
+	}
+
+	if len(diff) == 0 {
+		fmt.Printf("✅ %s is up to date\n", configPath)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is out of date (- current, + detected):\n%s\n", configPath, strings.Join(diff, "\n"))
+	fmt.Fprintln(os.Stderr, "Run 'octo init --force' to regenerate it.")
+	os.Exit(1)
+	return nil
+}
+
+// generateBlueprint builds the blueprint octo init would write, without prompting.
+// Where init asks the user to choose a workspace runner, the choice in the existing
+// configuration is kept if it is still a valid option.
+func generateBlueprint(cwd string, env string, skipSecrets bool, existing blueprint.Blueprint) (blueprint.Blueprint, error) {
+	projectInfo, err := analyzer.AnalyzeProjectWithOptions(cwd, analyzer.AnalysisOptions{Environment: env})
+	if err != nil {
+		return blueprint.Blueprint{}, fmt.Errorf("analysis failed: %w", err)
+	}
+
+	bp := blueprint.FromProjectInfo(projectInfo)
+
+	if len(projectInfo.WorkspaceRunners) > 0 {
+		bp.WorkspaceRunner = projectInfo.WorkspaceRunners[0]
+		for _, runner := range projectInfo.WorkspaceRunners {
+			if runner == existing.WorkspaceRunner {
+				bp.WorkspaceRunner = runner
+			}
+		}
+		if len(projectInfo.WorkspaceRunners) > 1 {
+			bp.RunCommand = analyzer.WorkspaceRunCommand(bp.WorkspaceRunner, projectInfo.PackageManager, bp.RunCommand)
+		}
+	}
+
+	bp.BaseImage = blueprint.DetectBaseImage(cwd)
+
+	if !skipSecrets {
+		envStatus, err := secrets.CheckEnvStatusWithReadme(cwd, projectInfo.Language)
+		if err != nil {
+			return blueprint.Blueprint{}, fmt.Errorf("failed to scan for environment variables: %w", err)
+		}
+		bp.EnvVars = envVarsFromSecrets(envStatus.Required)
+	}
+
+	return bp, nil
+}
+
+// envVarNames returns the sorted, comma-separated names of vars
+func envVarNames(vars []blueprint.EnvVar) string {
+	names := make([]string, len(vars))
+	for i, v := range vars {
+		names[i] = v.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// displayValue shows empty values explicitly in the --check diff
+func displayValue(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return value
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/shellquote"
+	"github.com/harshul/octo-cli/internal/ui"
+)
+
+// autoInitHooks are the git hooks --install-hooks writes, with the revision range each one
+// compares to decide whether a dependency manifest changed
+var autoInitHooks = []struct {
+	name      string
+	revisions string
+}{
+	{"post-checkout", `"$1" "$2"`},
+	{"post-merge", "ORIG_HEAD HEAD"},
+}
+
+// autoInitManifests are the dependency files whose changes make .octo.yaml stale
+var autoInitManifests = []string{"*package.json", "*go.mod", "*requirements.txt"}
+
+// Markers delimit the block octo adds so it can be appended to and removed from existing hooks
+const (
+	hookBlockStart = "# >>> octo init --auto >>>"
+	hookBlockEnd   = "# <<< octo init --auto <<<"
+)
+
+// runInitAuto refreshes the configuration without prompting, for use from git hooks.
+// Only the fields init derives from the project are updated, so hand-edited settings are kept,
+// and the file is only written when one of them changed. The run and setup commands are
+// never refreshed: the hook runs silently, so replacing a customised command would go unnoticed.
+func runInitAuto(cwd string, outputPath string, env string, skipSecrets bool) error {
+	existing, err := blueprint.Read(outputPath)
+	if os.IsNotExist(err) {
+		bp, err := generateBlueprint(cwd, env, skipSecrets, blueprint.Blueprint{})
+		if err != nil {
+			return err
+		}
+		if len(bp.EnvVars) > 0 {
+			bp.EnvVarGroups = blueprint.GroupEnvVars(bp.EnvVars)
+		}
+		if err := blueprint.Write(outputPath, bp); err != nil {
+			return fmt.Errorf("failed to write configuration: %w", err)
+		}
+		fmt.Printf("✅ Configuration written to %s\n", outputPath)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	fresh, err := generateBlueprint(cwd, env, skipSecrets, existing)
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+	for _, field := range generatedFields {
+		if field.name == "env_vars" && skipSecrets {
+			continue
+		}
+		if field.value(existing) != field.value(fresh) {
+			changed = append(changed, field.name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	updated := existing
+	updated.Language = fresh.Language
+	updated.Version = fresh.Version
+	updated.SetupRequired = fresh.SetupRequired
+	updated.PackageManager = fresh.PackageManager
+	updated.IsMonorepo = fresh.IsMonorepo
+	updated.MonorepoRoot = fresh.MonorepoRoot
+	updated.WorkspaceRunner = fresh.WorkspaceRunner
+	updated.BaseImage = fresh.BaseImage
+	if !skipSecrets && envVarNames(existing.AllEnvVars()) != envVarNames(fresh.AllEnvVars()) {
+		updated.EnvVars = fresh.EnvVars
+		updated.EnvVarGroups = nil
+		if len(fresh.EnvVars) > 0 {
+			updated.EnvVarGroups = blueprint.GroupEnvVars(fresh.EnvVars)
+		}
+	}
+
+	if err := blueprint.Write(outputPath, updated); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	fmt.Printf("🔄 Updated %s (%s)\n", outputPath, strings.Join(changed, ", "))
+	return nil
+}
+
+// gitHooksDir returns the hooks directory of the repository containing cwd,
+// honoring core.hooksPath, and cwd's path relative to the repository root
+func gitHooksDir(cwd string) (dir string, prefix string, err error) {
+	out, err := exec.Command("git", "-C", cwd, "rev-parse", "--git-path", "hooks", "--show-prefix").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("not inside a git repository")
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	dir = lines[0]
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cwd, dir)
+	}
+	if len(lines) > 1 {
+		prefix = strings.TrimSuffix(lines[1], "/")
+	}
+	return dir, prefix, nil
+}
+
+// autoInitHookBlock is the snippet added to a hook. Git runs hooks from the repository root,
+// so it changes to the directory init was run in before refreshing the configuration.
+func autoInitHookBlock(revisions string, prefix string, outputFlag string) string {
+	command := "octo init --auto" + outputFlag
+	if prefix != "" {
+		command = fmt.Sprintf("cd %s && %s", shellquote.Quote(prefix), command)
+	}
+
+	var b strings.Builder
+	b.WriteString(hookBlockStart + "\n")
+	b.WriteString("# Refresh the octo configuration when dependency manifests change\n")
+	fmt.Fprintf(&b, "if ! git diff --quiet %s -- %s 2>/dev/null; then\n", revisions, shellquote.Join(autoInitManifests))
+	fmt.Fprintf(&b, "  (%s) >/dev/null 2>&1 || true\n", command)
+	b.WriteString("fi\n")
+	b.WriteString(hookBlockEnd + "\n")
+	return b.String()
+}
+
+// installInitHooks adds the octo block to the post-checkout and post-merge hooks,
+// appending to hooks that already exist instead of overwriting them
+func installInitHooks(cwd string, outputFlag string, dryRun bool) error {
+	dir, prefix, err := gitHooksDir(cwd)
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	for _, hook := range autoInitHooks {
+		path := filepath.Join(dir, hook.name)
+		block := autoInitHookBlock(hook.revisions, prefix, outputFlag)
+
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		content := string(data)
+
+		var done, planned string
+		switch {
+		case strings.Contains(content, hookBlockStart):
+			content = removeHookBlock(content) + block
+			done, planned = "🔄 Updated octo block in", "update the octo block in"
+		case content == "":
+			content = "#!/bin/sh\n" + block
+			done, planned = "🪝 Installed", "install"
+		default:
+			if !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			content += "\n" + block
+			done, planned = "🪝 Appended to existing", "append to existing"
+		}
+
+		if dryRun {
+			ui.PrintInfo(fmt.Sprintf("Dry run: would %s %s", planned, path))
+			continue
+		}
+		fmt.Printf("%s %s\n", done, path)
+
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		// WriteFile keeps the mode of an existing file, which may not be executable
+		if err := os.Chmod(path, 0755); err != nil {
+			return fmt.Errorf("failed to make %s executable: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// removeInitHooks removes the octo block from the hooks, deleting hooks that contained nothing else
+func removeInitHooks(cwd string, dryRun bool) error {
+	dir, _, err := gitHooksDir(cwd)
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	for _, hook := range autoInitHooks {
+		path := filepath.Join(dir, hook.name)
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), hookBlockStart) {
+			continue
+		}
+
+		content := removeHookBlock(string(data))
+		removed = true
+		if dryRun {
+			ui.PrintInfo(fmt.Sprintf("Dry run: would remove the octo block from %s", path))
+			continue
+		}
+		if strings.TrimSpace(strings.TrimPrefix(content, "#!/bin/sh")) == "" {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			fmt.Printf("🗑️  Removed %s\n", path)
+		} else {
+			if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			fmt.Printf("🗑️  Removed octo block from %s\n", path)
+		}
+	}
+
+	if !removed {
+		fmt.Println("ℹ️  No octo git hooks installed")
+	}
+	return nil
+}
+
+// removeHookBlock strips the octo block (and the blank line separating it) from a hook script
+func removeHookBlock(content string) string {
+	start := strings.Index(content, hookBlockStart)
+	if start < 0 {
+		return content
+	}
+	end := strings.Index(content[start:], hookBlockEnd)
+	if end < 0 {
+		return content
+	}
+	end = start + end + len(hookBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return strings.TrimRight(content[:start], "\n") + "\n" + content[end:]
+}
+
@@ -22,7 +22,11 @@ and generates a deployment configuration file.
 
 Usage:
   octo init    Analyze the codebase and generate a .octo.yaml file
-  octo run     Execute the software based on the .octo.yaml file`,
+  octo run     Execute the software based on the .octo.yaml file
+  octo schema  Print the JSON Schema for .octo.yaml
+  octo ps      List running octo-managed projects and their ports
+  octo signal  Send a signal (e.g. SIGHUP) to the running app
+  octo stop    Stop the octo session running in this directory`,
 	Version: version,
 }
 
@@ -30,6 +34,10 @@ func init() {
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(psCmd)
+	rootCmd.AddCommand(signalCmd)
+	rootCmd.AddCommand(stopCmd)
 }
 
 func main() {
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// psLastLogWidth truncates the LAST LOG column so rows fit on one line
+const psLastLogWidth = 50
+
+// psCmd represents the ps command
+var psCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "List running octo-managed projects and their ports",
+	Long: `The ps command lists every active 'octo run' session on this machine,
+with the port each app is listening on, its working directory, how long
+it has been running and the last line it logged.
+
+Sessions register themselves in ~/.octo/pids while they run.
+Use --kill-all to gracefully stop every listed session.`,
+	Args: cobra.NoArgs,
+	RunE: runPs,
+}
+
+func init() {
+	psCmd.Flags().Bool("kill-all", false, "Stop all listed sessions (SIGTERM, forwarded to each app)")
+}
+
+func runPs(cmd *cobra.Command, args []string) error {
+	killAll, _ := cmd.Flags().GetBool("kill-all")
+
+	infos, err := ports.ListOctoManagedPorts()
+	if err != nil {
+		return fmt.Errorf("failed to list running projects: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(infos) == 0 {
+		fmt.Fprintln(out, "No octo-managed projects are running.")
+		return nil
+	}
+
+	if killAll {
+		for _, info := range infos {
+			// octo forwards SIGTERM to the app and escalates to SIGKILL after a timeout
+			if err := syscall.Kill(info.PID, syscall.SIGTERM); err != nil {
+				fmt.Fprintf(out, "❌ Failed to stop %s (PID %d): %v\n", info.Project, info.PID, err)
+				continue
+			}
+			fmt.Fprintf(out, "⏹️  Stopping %s (PID %d)\n", info.Project, info.PID)
+		}
+		return nil
+	}
+
+	home, _ := os.UserHomeDir()
+
+	fmt.Fprintf(out, "%-24s %-8s %-6s %-32s %-10s %s\n", "PROJECT", "PID", "PORT", "WORKDIR", "UPTIME", "LAST LOG")
+	for _, info := range infos {
+		port := "-"
+		if info.Port > 0 {
+			port = strconv.Itoa(info.Port)
+		}
+
+		workDir := info.WorkDir
+		if home != "" && strings.HasPrefix(workDir, home) {
+			workDir = "~" + strings.TrimPrefix(workDir, home)
+		}
+
+		lastLog := info.LastLog
+		if len(lastLog) > psLastLogWidth {
+			lastLog = lastLog[:psLastLogWidth-3] + "..."
+		}
+
+		uptime := time.Since(info.StartTime).Round(time.Second)
+		fmt.Fprintf(out, "%-24s %-8d %-6s %-32s %-10s %s\n", info.Project, info.PID, port, workDir, uptime, lastLog)
+	}
+	return nil
+}
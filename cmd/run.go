@@ -3,10 +3,17 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/harshul/octo-cli/internal/analyzer"
 	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/config"
+	"github.com/harshul/octo-cli/internal/metrics"
 	"github.com/harshul/octo-cli/internal/orchestrator"
 	"github.com/harshul/octo-cli/internal/secrets"
 	"github.com/harshul/octo-cli/internal/ui"
@@ -27,28 +34,104 @@ It will:
 - Start your application
 
 The execution method (Docker, Nix, or Shell) is determined by your
-configuration and system capabilities.`,
+configuration and system capabilities.
+
+Set OCTO_RUN_COMMAND to override the configured run command, e.g. in a
+devcontainer definition. --command takes precedence over it.
+
+Defaults for flags like --thermal-mode, --shell or --no-tui can be set in
+~/.octo/config.yaml (e.g. "thermal: {mode: cool}"). They override the
+project's .octo.yaml, and flags given on the command line override them.
+
+Without a .octo.yaml, the project is analyzed on the fly and run with the
+detected settings; nothing is written to disk. Use --require-config to fail
+instead.`,
 	RunE: runRun,
 }
 
 func init() {
 	// Add flags specific to the run command
 	runCmd.Flags().StringP("config", "c", ".octo.yaml", "Path to the configuration file")
+	runCmd.Flags().String("cwd", "", "Run the project in this directory instead of the current one (it must contain .octo.yaml unless --config is given)")
+	runCmd.Flags().Bool("require-config", false, "Fail when .octo.yaml is missing instead of running with settings detected on the fly")
+	runCmd.Flags().Int("config-search-depth", 5, "How many parent directories to search for .octo.yaml when the current one has none")
+	// -c is already --config, so --command has no shorthand
+	runCmd.Flags().String("command", "", "Run this command instead of the configured run command (without editing .octo.yaml)")
 	runCmd.Flags().StringP("env", "e", "development", "Environment to run (development, production)")
 	runCmd.Flags().BoolP("build", "b", true, "Run build step before execution")
+	runCmd.Flags().Duration("skip-build-if-recent", 0, "Skip rebuilding a local binary built within this long (e.g. 5m) if no source file changed since")
 	runCmd.Flags().BoolP("watch", "w", false, "Watch for file changes and restart")
+	runCmd.Flags().String("watch-command", "", "With --watch, run this command (e.g. tests or a linter) after each change, before restarting")
 	runCmd.Flags().BoolP("detach", "d", false, "Run in detached mode (background)")
 	runCmd.Flags().IntP("port", "p", 0, "Override the port to run on (0 = use config default)")
 	runCmd.Flags().Bool("no-port-shift", false, "Disable automatic port shifting on conflicts")
-	runCmd.Flags().Bool("skip-env-check", false, "Skip environment variable validation")
+	runCmd.Flags().Bool("skip-port-check", false, "Don't check whether the port is already in use (implies --no-port-shift)")
+	runCmd.Flags().Bool("no-setup", false, "Don't run the setup command before starting the app")
+	runCmd.Flags().Bool("skip-setup", false, "Deprecated alias of --no-setup")
+	runCmd.Flags().MarkDeprecated("skip-setup", "use --no-setup instead")
+	runCmd.Flags().Bool("no-env-check", false, "Don't validate or auto-provision environment variables before starting")
+	runCmd.Flags().Bool("skip-env-check", false, "Deprecated alias of --no-env-check")
+	runCmd.Flags().MarkDeprecated("skip-env-check", "use --no-env-check instead")
+	runCmd.Flags().Bool("env-validate-strict", false, "Fail if a required environment variable is unset instead of prompting (for CI and scripts)")
 	runCmd.Flags().Bool("no-tui", false, "Disable TUI dashboard (use plain scrolling output)")
+	runCmd.Flags().Bool("no-browser", false, "Print the URL of HTML projects instead of opening a browser (for SSH, CI and Docker)")
+	runCmd.Flags().Bool("no-monorepo-link", false, "Skip linking pnpm/bun workspace packages (when a previous step already installed them)")
+	runCmd.Flags().String("theme", "", fmt.Sprintf("Dashboard color theme (%s); saved as your default in ~/.octo/config.yaml", strings.Join(ui.ThemeNames(), ", ")))
+	runCmd.Flags().Int("max-log-lines", ui.DefaultMaxLogLines, "Log lines the dashboard keeps per project")
+	runCmd.Flags().Int("max-log-bytes", 0, "Drop the oldest dashboard log lines once all projects' logs together exceed this many bytes (0 = no limit)")
+	runCmd.Flags().BoolP("quiet", "q", false, "Hide octo's own messages and show only the app's output; fails instead of prompting for missing env vars (implies --no-tui)")
+	runCmd.Flags().IntP("concurrency", "j", 0, "Override the worker count (0 = use thermal detection)")
+	runCmd.Flags().String("thermal-mode", "", "Override the configured thermal mode (auto, performance, balanced, cool, manual)")
+	runCmd.Flags().StringSlice("services", nil, "Only start the named services or their aliases from the configuration (comma-separated)")
+	runCmd.Flags().Bool("metrics", false, "Expose Prometheus metrics for the running process")
+	runCmd.Flags().Int("metrics-port", metrics.DefaultPort, "Port for the --metrics endpoint")
+	runCmd.Flags().String("shell", "sh", "Shell used to run commands (sh, bash, zsh, or an absolute path)")
+	runCmd.Flags().String("pid-file", "", "Write the process PID to this file (removed on exit)")
+	runCmd.Flags().StringArray("wait-for", nil, "Wait until host:port accepts connections before starting (repeatable)")
+	runCmd.Flags().StringArray("forward-port", nil, "Expose a local port on another address, as external-host:external-port:local-port (repeatable)")
+	runCmd.Flags().Float64("rate-limit", 0, "Limit HTTP requests through --forward-port to this many per second, to simulate slow networks")
+	runCmd.Flags().Int("latency", 0, "Add this many milliseconds of latency to each HTTP request through --forward-port")
+	runCmd.Flags().Int("wait-timeout", int(orchestrator.DefaultWaitTimeout.Seconds()), "Seconds to wait for --wait-for addresses")
+	runCmd.Flags().Bool("with-compose", false, "Start the docker-compose.yml services before setup and stop them on exit")
+	runCmd.Flags().Bool("notify", false, "Send a desktop notification with the app URL once the server is ready")
+	runCmd.Flags().Bool("benchmark", false, "Measure startup time and save it to ~/.octo/benchmarks")
+	runCmd.Flags().String("exit-after", "", "Stop the app and exit 0 once an output line matches this regex (e.g. \"Server started on port\")")
+	runCmd.Flags().String("url-template", "", "Dashboard URL format, e.g. \"https://{project}.local:{port}{path}\" (tokens: {project}, {port}, {host}, {path})")
+	runCmd.Flags().Bool("skip-doppler", false, "Don't load secrets from Doppler even if doppler.yaml is present (or set OCTO_SKIP_DOPPLER=1)")
+	runCmd.Flags().Bool("print-command", false, "Print the fully resolved setup/run commands and injected environment without running anything")
+	runCmd.Flags().Bool("dry-run", false, "Alias of --print-command")
+	runCmd.Flags().Bool("export-pid", false, "Start the app in the background, print only its PID and exit (combine with --pid-file)")
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
+	// ~/.octo/config.yaml supplies defaults for flags not given on the command line
+	globalConfig, err := config.Load()
+	if err != nil {
+		ui.Warn(fmt.Sprintf("Could not read global configuration: %v", err))
+	}
+	if err := applyGlobalFlags(cmd, globalConfig.RunFlags()); err != nil {
+		return err
+	}
+
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	printCommand, _ := cmd.Flags().GetBool("print-command")
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		printCommand = true
+	}
+	exportPID, _ := cmd.Flags().GetBool("export-pid")
+	maxLogLines, _ := cmd.Flags().GetInt("max-log-lines")
+	maxLogBytes, _ := cmd.Flags().GetInt("max-log-bytes")
+	// Only the resolved command (or the PID) goes to stdout
+	if printCommand || exportPID {
+		quiet = true
+	}
+
 	// ========================================
 	// Show intro animation
 	// ========================================
-	ui.RunIntro()
+	if !quiet {
+		ui.RunIntro()
+	}
 
 	// Get current working directory
 	cwd, err := os.Getwd()
@@ -58,32 +141,206 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 	// Get flag values
 	configPath, _ := cmd.Flags().GetString("config")
+	workDirFlag, _ := cmd.Flags().GetString("cwd")
+	configSearchDepth, _ := cmd.Flags().GetInt("config-search-depth")
+	requireConfig, _ := cmd.Flags().GetBool("require-config")
 	env, _ := cmd.Flags().GetString("env")
 	build, _ := cmd.Flags().GetBool("build")
+	skipBuildIfRecent, _ := cmd.Flags().GetDuration("skip-build-if-recent")
 	watch, _ := cmd.Flags().GetBool("watch")
 	detach, _ := cmd.Flags().GetBool("detach")
 	port, _ := cmd.Flags().GetInt("port")
 	noPortShift, _ := cmd.Flags().GetBool("no-port-shift")
+	skipPortCheck, _ := cmd.Flags().GetBool("skip-port-check")
+	if skipPortCheck {
+		noPortShift = true
+	}
+	// --skip-setup and --skip-env-check are deprecated aliases of the --no-* flags
+	noSetup, _ := cmd.Flags().GetBool("no-setup")
+	skipSetup, _ := cmd.Flags().GetBool("skip-setup")
+	noEnvCheck, _ := cmd.Flags().GetBool("no-env-check")
 	skipEnvCheck, _ := cmd.Flags().GetBool("skip-env-check")
+	skipSetup = skipSetup || noSetup
+	skipEnvCheck = skipEnvCheck || noEnvCheck
+	envValidateStrict, _ := cmd.Flags().GetBool("env-validate-strict")
 	noTUI, _ := cmd.Flags().GetBool("no-tui")
+	noBrowser, _ := cmd.Flags().GetBool("no-browser")
+	noMonorepoLink, _ := cmd.Flags().GetBool("no-monorepo-link")
+	theme, _ := cmd.Flags().GetString("theme")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	thermalMode, _ := cmd.Flags().GetString("thermal-mode")
+	services, _ := cmd.Flags().GetStringSlice("services")
+	enableMetrics, _ := cmd.Flags().GetBool("metrics")
+	metricsPort, _ := cmd.Flags().GetInt("metrics-port")
+	shell, _ := cmd.Flags().GetString("shell")
+	pidFile, _ := cmd.Flags().GetString("pid-file")
+	skipDoppler, _ := cmd.Flags().GetBool("skip-doppler")
+	benchmark, _ := cmd.Flags().GetBool("benchmark")
+	notify, _ := cmd.Flags().GetBool("notify")
+	withCompose, _ := cmd.Flags().GetBool("with-compose")
+	waitFor, _ := cmd.Flags().GetStringArray("wait-for")
+	forwardPortSpecs, _ := cmd.Flags().GetStringArray("forward-port")
+	rateLimit, _ := cmd.Flags().GetFloat64("rate-limit")
+	latency, _ := cmd.Flags().GetInt("latency")
+	waitTimeout, _ := cmd.Flags().GetInt("wait-timeout")
+	urlTemplate, _ := cmd.Flags().GetString("url-template")
+	exitAfter, _ := cmd.Flags().GetString("exit-after")
+	runCommand, _ := cmd.Flags().GetString("command")
+	watchCommand, _ := cmd.Flags().GetString("watch-command")
 	
-	// Dashboard is enabled by default unless --no-tui is specified or running in detached mode
-	useDashboard := !noTUI && !detach
+	// Dashboard is enabled by default unless --no-tui or --quiet is specified or running in detached mode
+	useDashboard := !noTUI && !quiet && !detach
+
+	// An explicit --config is relative to where octo was invoked, not to --cwd
+	if !filepath.IsAbs(configPath) && cmd.Flags().Changed("config") {
+		configPath = filepath.Join(cwd, configPath)
+	}
+
+	// Switch to the --cwd directory so every phase runs there. Without --config
+	// the directory must hold the configuration file.
+	if workDirFlag != "" {
+		requiredConfig := ""
+		if !cmd.Flags().Changed("config") {
+			requiredConfig = configPath
+		}
+		workDir, err := resolveWorkDir(workDirFlag, requiredConfig)
+		if err != nil {
+			return err
+		}
+		if err := os.Chdir(workDir); err != nil {
+			return fmt.Errorf("failed to change to %s: %w", workDir, err)
+		}
+		cwd = workDir
+	}
 
 	// Resolve config path
 	if !filepath.IsAbs(configPath) {
 		configPath = filepath.Join(cwd, configPath)
 	}
 
-	// Check if configuration file exists
+	// Inside a workspace package, fall back to the workspace root's config and run from there
+	if _, err := os.Stat(configPath); os.IsNotExist(err) && !cmd.Flags().Changed("config") {
+		if found, ok := findConfigInParents(cwd, filepath.Base(configPath), configSearchDepth); ok {
+			cwd = filepath.Dir(found)
+			if err := os.Chdir(cwd); err != nil {
+				return fmt.Errorf("failed to change to %s: %w", cwd, err)
+			}
+			configPath = found
+			if !quiet {
+				fmt.Printf("📂 Using %s\n", found)
+			}
+		}
+	}
+
+	// Without a configuration file, run with a blueprint detected in memory (nothing is written)
+	var bp blueprint.Blueprint
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf("configuration file not found at %s. Run 'octo init' first", configPath)
+		if requireConfig || cmd.Flags().Changed("config") {
+			return fmt.Errorf("configuration file not found at %s. Run 'octo init' first", configPath)
+		}
+		bp, err = detectBlueprint(cwd, env)
+		if err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Printf("💡 No %s found - running with detected settings. Run 'octo init' to save them\n", filepath.Base(configPath))
+		}
+	} else {
+		bp, err = blueprint.Read(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read configuration: %w", err)
+		}
 	}
 
-	// Read the blueprint
-	bp, err := blueprint.Read(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read configuration: %w", err)
+	// OCTO_RUN_COMMAND lets devcontainers and Codespaces replace the run command from
+	// their environment; an explicit --command still wins
+	if envCommand := strings.TrimSpace(os.Getenv("OCTO_RUN_COMMAND")); envCommand != "" && !cmd.Flags().Changed("command") {
+		bp.RunCommand = envCommand
+		if !quiet {
+			fmt.Printf("🔧 Using run command from OCTO_RUN_COMMAND: %s\n", envCommand)
+		}
+	}
+
+	// --command replaces the run command for this invocation only; setup, port handling
+	// and env injection still apply to it
+	if cmd.Flags().Changed("command") {
+		if strings.TrimSpace(runCommand) == "" {
+			return fmt.Errorf("--command must not be empty")
+		}
+		bp.RunCommand = runCommand
+	}
+
+	if skipBuildIfRecent < 0 {
+		return fmt.Errorf("--skip-build-if-recent must not be negative")
+	}
+
+	if maxLogLines < 0 || maxLogBytes < 0 {
+		return fmt.Errorf("--max-log-lines and --max-log-bytes must not be negative")
+	}
+
+	if watchCommand != "" && !watch {
+		return fmt.Errorf("--watch-command requires --watch")
+	}
+
+	// The app outlives octo with --export-pid, so nothing octo keeps running alongside it can be used
+	if exportPID {
+		for _, name := range []string{"watch", "exit-after", "benchmark", "with-compose", "forward-port", "metrics"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--export-pid cannot be combined with --%s", name)
+			}
+		}
+	}
+
+	var exitAfterPattern *regexp.Regexp
+	if exitAfter != "" {
+		if watch {
+			return fmt.Errorf("--exit-after cannot be combined with --watch")
+		}
+		exitAfterPattern, err = regexp.Compile(exitAfter)
+		if err != nil {
+			return fmt.Errorf("invalid --exit-after pattern: %w", err)
+		}
+	}
+
+	var forwardPorts []orchestrator.PortForward
+	for _, spec := range forwardPortSpecs {
+		fwd, err := orchestrator.ParsePortForward(spec)
+		if err != nil {
+			return err
+		}
+		forwardPorts = append(forwardPorts, fwd)
+	}
+	if rateLimit < 0 || latency < 0 {
+		return fmt.Errorf("--rate-limit and --latency must not be negative")
+	}
+	if (rateLimit > 0 || latency > 0) && len(forwardPorts) == 0 {
+		return fmt.Errorf("--rate-limit and --latency shape traffic through --forward-port; add a --forward-port")
+	}
+
+	// --thermal-mode takes precedence over thermal.mode in the configuration
+	if cmd.Flags().Changed("thermal-mode") {
+		strategy, err := blueprint.ParseConcurrencyStrategy(thermalMode)
+		if err != nil {
+			return err
+		}
+		bp.Thermal.Mode = strategy
+	}
+
+	// An explicit --theme becomes the default for later runs; otherwise use the saved one
+	if cmd.Flags().Changed("theme") {
+		if _, err := ui.ThemeStyles(theme); err != nil {
+			return err
+		}
+		if err := config.Set("theme", theme); err != nil {
+			ui.Warn(fmt.Sprintf("Could not save theme preference: %v", err))
+		}
+	} else {
+		theme = globalConfig.Theme
+	}
+
+	// Validate requested services before doing any work
+	if _, _, err := bp.ResolveServices(cwd, services); err != nil {
+		return err
 	}
 
 	// Check if running inside the Octo project itself
@@ -93,14 +350,14 @@ func runRun(cmd *cobra.Command, args []string) error {
 	}
 
 	// Pre-run environment validation and auto-provisioning
-	if !skipEnvCheck {
+	if !skipEnvCheck && !printCommand {
 		valid, _ := secrets.PreRunEnvValidation(cwd, bp.Language)
 		if !valid {
 			// Auto-provision missing env files with README defaults (don't show scary warnings first)
-			result, err := secrets.AutoProvisionEnvFiles(cwd, bp.Language)
+			result, err := secrets.AutoProvisionEnvFiles(cwd, bp.Language, false)
 			if err != nil {
 				ui.Warn(fmt.Sprintf("Failed to auto-provision environment: %v", err))
-			} else if len(result.ProvisionedVars) > 0 || len(result.CreatedFiles) > 0 {
+			} else if !quiet && (len(result.ProvisionedVars) > 0 || len(result.CreatedFiles) > 0) {
 				// Show success message about what was auto-configured
 				fmt.Println()
 				fmt.Println("🔧 Auto-configuring environment...")
@@ -131,15 +388,24 @@ func runRun(cmd *cobra.Command, args []string) error {
 			// Re-validate after auto-provisioning
 			valid, issues := secrets.PreRunEnvValidation(cwd, bp.Language)
 			if !valid {
+				// --quiet hides the prompt below, so fail instead of waiting on stdin
+				if quiet {
+					return fmt.Errorf("environment validation failed: %s (run without --quiet to review it)", strings.Join(issues, "; "))
+				}
+
 				// Only show issues that remain AFTER auto-provisioning
 				ui.DisplayPreRunEnvValidation(issues)
+
+				if envValidateStrict {
+					return fmt.Errorf("environment validation failed (--env-validate-strict)")
+				}
 				
 				// Ask if user wants to continue anyway
 				if !ui.PromptContinueDespiteEnvIssues() {
 					ui.Info("Run 'octo init' to configure environment variables.")
 					return fmt.Errorf("aborted due to environment configuration issues")
 				}
-			} else {
+			} else if !quiet {
 				// Everything was auto-fixed!
 				ui.Success("Environment configured successfully!")
 				fmt.Println()
@@ -147,19 +413,56 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	ui.Info(fmt.Sprintf("Running %s in %s mode...", bp.Name, env))
+	if !quiet {
+		if bp.Warning != "" {
+			ui.Warn(bp.Warning)
+		}
+		ui.Info(fmt.Sprintf("Running %s in %s mode...", bp.Name, env))
+	}
 
 	// Create orchestrator options
 	opts := orchestrator.Options{
-		WorkDir:      cwd,
-		Environment:  env,
-		RunBuild:     build,
-		Watch:        watch,
-		Detach:       detach,
-		PortOverride: port,
-		NoPortShift:  noPortShift,
-		SkipEnvCheck: skipEnvCheck,
-		UseDashboard: useDashboard,
+		WorkDir:       cwd,
+		Environment:   env,
+		RunBuild:      build,
+		Watch:         watch,
+		Detach:        detach,
+		PortOverride:  port,
+		NoPortShift:   noPortShift,
+		SkipPortCheck: skipPortCheck,
+		SkipSetup:     skipSetup,
+		SkipEnvCheck:  skipEnvCheck,
+		UseDashboard:  useDashboard,
+		Concurrency:   concurrency,
+		Services:      services,
+		Shell:         shell,
+		PIDFile:       pidFile,
+		SkipDoppler:   skipDoppler,
+		Benchmark:     benchmark,
+		Notify:        notify,
+		WithCompose:   withCompose,
+		WaitFor:       waitFor,
+		WaitTimeout:   time.Duration(waitTimeout) * time.Second,
+		URLTemplate:   urlTemplate,
+		ExitAfter:     exitAfterPattern,
+		Quiet:         quiet,
+		NoBrowser:     noBrowser,
+		Theme:         theme,
+
+		StrictEnvValidation: envValidateStrict,
+		NoMonorepoLink:      noMonorepoLink,
+		ForwardPorts:        forwardPorts,
+		SkipBuildIfRecent:   skipBuildIfRecent,
+		RateLimit:           rateLimit,
+		Latency:             time.Duration(latency) * time.Millisecond,
+		PrintCommand:        printCommand,
+		WatchCommand:        watchCommand,
+		ExportPID:           exportPID,
+		MaxLogLines:         maxLogLines,
+		MaxLogBytes:         maxLogBytes,
+	}
+	if enableMetrics {
+		opts.MetricsPort = metricsPort
 	}
 
 	// Create and run the orchestrator
@@ -168,6 +471,26 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create orchestrator: %w", err)
 	}
 
+	if printCommand {
+		return orch.PrintCommand()
+	}
+
+	// Forward SIGINT/SIGTERM/SIGHUP to the app so it can shut down gracefully.
+	// The dashboard and watch mode install their own handlers.
+	if !useDashboard && !watch {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		defer signal.Stop(sigChan)
+
+		go func() {
+			for sig := range sigChan {
+				// Stop also interrupts setup, installs and --wait-for; Run then returns
+				// normally so Compose services, the PID file and the session entry are cleaned up
+				orch.Stop(sig)
+			}
+		}()
+	}
+
 	// Execute the application
 	if useDashboard {
 		if err := orch.RunWithDashboard(); err != nil {
@@ -182,6 +505,91 @@ func runRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// applyGlobalFlags sets each flag from ~/.octo/config.yaml that was not given on the command line
+func applyGlobalFlags(cmd *cobra.Command, flags map[string]string) error {
+	for name, value := range flags {
+		if cmd.Flags().Changed(name) {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s %q in ~/.octo/config.yaml: %w", name, value, err)
+		}
+	}
+	return nil
+}
+
+// resolveWorkDir expands and validates the --cwd directory.
+// When configName is set the directory must contain that configuration file.
+func resolveWorkDir(path string, configName string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid --cwd %s: %w", path, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("--cwd directory %s does not exist", abs)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--cwd %s is not a directory", abs)
+	}
+	if configName != "" {
+		if _, err := os.Stat(filepath.Join(abs, configName)); err != nil {
+			return "", fmt.Errorf("--cwd directory %s has no %s. Run 'octo init' there or pass --config", abs, configName)
+		}
+	}
+
+	return abs, nil
+}
+
+// detectBlueprint analyzes the project like a minimal 'octo init' would, without
+// prompts, dependency installation or writing .octo.yaml
+func detectBlueprint(dir string, env string) (blueprint.Blueprint, error) {
+	projectInfo, err := analyzer.AnalyzeProjectWithOptions(dir, analyzer.AnalysisOptions{Environment: env})
+	if err != nil {
+		return blueprint.Blueprint{}, fmt.Errorf("analysis failed: %w", err)
+	}
+	if projectInfo.RunCommand == "" {
+		return blueprint.Blueprint{}, fmt.Errorf("no .octo.yaml found and no runnable project detected in %s. Run 'octo init' first", dir)
+	}
+
+	bp := blueprint.FromProjectInfo(projectInfo)
+	if bp.Name == "" {
+		bp.Name = filepath.Base(dir)
+	}
+	return bp, nil
+}
+
+// findConfigInParents walks up from dir looking for a config file named name, checking at most
+// maxDepth parent directories. The search stops at the filesystem root and at the first
+// directory containing .git, so it never leaves the repository.
+func findConfigInParents(dir string, name string, maxDepth int) (string, bool) {
+	for i := 0; i < maxDepth; i++ {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // maskEnvValue masks sensitive values for display
 func maskEnvValue(value string) string {
 	// Don't mask URLs - they're usually not secret
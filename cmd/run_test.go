@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProjectFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestDetectBlueprint(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		env      string
+		language string
+		run      string
+	}{
+		{
+			name:     "node development",
+			files:    map[string]string{"package.json": `{"name": "shop", "scripts": {"dev": "vite", "start": "node server.js"}}`},
+			env:      "development",
+			language: "Node",
+			run:      "npm run dev",
+		},
+		{
+			name:     "node production",
+			files:    map[string]string{"package.json": `{"name": "shop", "scripts": {"dev": "vite", "start": "node server.js"}}`},
+			env:      "production",
+			language: "Node",
+			run:      "npm start",
+		},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		for name, content := range tt.files {
+			writeProjectFile(t, dir, name, content)
+		}
+
+		bp, err := detectBlueprint(dir, tt.env)
+		if err != nil {
+			t.Errorf("%s: detectBlueprint returned error: %v", tt.name, err)
+			continue
+		}
+		if bp.Name != "shop" || bp.Language != tt.language || bp.RunCommand != tt.run {
+			t.Errorf("%s: detectBlueprint = %q/%q/%q, want shop/%q/%q", tt.name, bp.Name, bp.Language, bp.RunCommand, tt.language, tt.run)
+		}
+		// Nothing is written: the detected blueprint only lives for this run
+		if _, err := os.Stat(filepath.Join(dir, ".octo.yaml")); !os.IsNotExist(err) {
+			t.Errorf("%s: expected detectBlueprint not to write .octo.yaml", tt.name)
+		}
+	}
+}
+
+func TestDetectBlueprintNothingRunnable(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFile(t, dir, "notes.txt", "just notes\n")
+
+	_, err := detectBlueprint(dir, "development")
+	if err == nil || !strings.Contains(err.Error(), "Run 'octo init' first") {
+		t.Errorf("detectBlueprint error = %v, want it to suggest octo init", err)
+	}
+}
+
+func TestResolveWorkDir(t *testing.T) {
+	project := t.TempDir()
+	writeProjectFile(t, project, ".octo.yaml", "name: shop\n")
+	empty := t.TempDir()
+	writeProjectFile(t, empty, "notes.txt", "just notes\n")
+
+	if got, err := resolveWorkDir(project, ".octo.yaml"); err != nil || got != project {
+		t.Errorf("resolveWorkDir(%q) = %q, %v; want %q", project, got, err, project)
+	}
+	// An explicit --config does not need to live in the --cwd directory
+	if got, err := resolveWorkDir(empty, ""); err != nil || got != empty {
+		t.Errorf("resolveWorkDir(%q) without a config = %q, %v; want %q", empty, got, err, empty)
+	}
+
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{empty, "has no .octo.yaml"},
+		{filepath.Join(empty, "missing"), "does not exist"},
+		{filepath.Join(empty, "notes.txt"), "is not a directory"},
+	}
+	for _, tt := range tests {
+		if _, err := resolveWorkDir(tt.path, ".octo.yaml"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("resolveWorkDir(%q) error = %v, want it to contain %q", tt.path, err, tt.wantErr)
+		}
+	}
+}
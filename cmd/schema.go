@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for .octo.yaml",
+	Long: `The schema command prints a JSON Schema describing the .octo.yaml
+configuration file to stdout.
+
+Point your editor's YAML language server at it to get autocompletion
+and validation, for example:
+
+  octo schema > octo.schema.json
+
+and add this line to the top of .octo.yaml:
+
+  # yaml-language-server: $schema=./octo.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: runSchema,
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	schema, err := blueprint.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/harshul/octo-cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// signalNames maps the signals apps commonly handle to their syscall values
+var signalNames = map[string]syscall.Signal{
+	"SIGHUP":   syscall.SIGHUP,
+	"SIGINT":   syscall.SIGINT,
+	"SIGQUIT":  syscall.SIGQUIT,
+	"SIGKILL":  syscall.SIGKILL,
+	"SIGUSR1":  syscall.SIGUSR1,
+	"SIGUSR2":  syscall.SIGUSR2,
+	"SIGTERM":  syscall.SIGTERM,
+	"SIGCONT":  syscall.SIGCONT,
+	"SIGSTOP":  syscall.SIGSTOP,
+	"SIGTSTP":  syscall.SIGTSTP,
+	"SIGWINCH": syscall.SIGWINCH,
+}
+
+// signalCmd represents the signal command
+var signalCmd = &cobra.Command{
+	Use:   "signal",
+	Short: "Send a signal to the app started by 'octo run'",
+	Long: `The signal command sends a signal to the running app, for example to make
+a server reload its configuration on SIGHUP or SIGUSR1.
+
+The app's PID is read from the file written by 'octo run --pid-file'
+(.octo.pid by default). That PID is the shell the run command was started
+in, so the signal is sent to the processes the shell started; a shell that
+replaced itself with the app is signalled directly. Signals can be given by
+name (SIGUSR1, USR1) or by number (10).`,
+	Args: cobra.NoArgs,
+	RunE: runSignal,
+}
+
+func init() {
+	signalCmd.Flags().StringP("signal", "s", "SIGHUP", "Signal to send, by name (SIGUSR1) or number (10)")
+	signalCmd.Flags().String("pid-file", ".octo.pid", "PID file written by 'octo run --pid-file'")
+}
+
+func runSignal(cmd *cobra.Command, args []string) error {
+	sigName, _ := cmd.Flags().GetString("signal")
+	pidFile, _ := cmd.Flags().GetString("pid-file")
+
+	sig, err := parseSignal(sigName)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			abs, _ := filepath.Abs(pidFile)
+			return fmt.Errorf("no PID file at %s. Start the app with 'octo run --pid-file %s'", abs, pidFile)
+		}
+		return fmt.Errorf("failed to read PID file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return fmt.Errorf("invalid PID in %s: %q", pidFile, strings.TrimSpace(string(data)))
+	}
+	if !ports.IsProcessAlive(pid) {
+		return fmt.Errorf("process %d from %s is not running", pid, pidFile)
+	}
+
+	// The PID file holds the shell octo runs the command in. A shell that did not exec the app
+	// would die on SIGHUP or SIGUSR1 without passing the signal on, so the app is reached
+	// through the shell's descendants; the PID itself is signalled only when it has none.
+	targets := ports.DescendantPIDs(pid)
+	if len(targets) == 0 {
+		targets = []int{pid}
+	}
+
+	for _, target := range targets {
+		if err := syscall.Kill(target, sig); err != nil {
+			return fmt.Errorf("failed to send %s to process %d: %w", sigName, target, err)
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "📡 Sent %s to %s\n", signalName(sig), describePIDs(targets))
+	return nil
+}
+
+// describePIDs names the signalled processes for the confirmation message
+func describePIDs(pids []int) string {
+	if len(pids) == 1 {
+		return fmt.Sprintf("process %d", pids[0])
+	}
+	names := make([]string, len(pids))
+	for i, pid := range pids {
+		names[i] = strconv.Itoa(pid)
+	}
+	return "processes " + strings.Join(names, ", ")
+}
+
+// signalName returns the SIG-prefixed name of sig, or its number if it has no known name
+func signalName(sig syscall.Signal) string {
+	for name, s := range signalNames {
+		if s == sig {
+			return name
+		}
+	}
+	return strconv.Itoa(int(sig))
+}
+
+// parseSignal accepts a signal name with or without the SIG prefix, or a signal number
+func parseSignal(value string) (syscall.Signal, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.Atoi(value); err == nil {
+		if n <= 0 || n > 64 {
+			return 0, fmt.Errorf("invalid signal number %d", n)
+		}
+		return syscall.Signal(n), nil
+	}
+
+	name := strings.ToUpper(value)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if sig, ok := signalNames[name]; ok {
+		return sig, nil
+	}
+	return 0, fmt.Errorf("unknown signal %q (use a name like SIGUSR1 or a number like 10)", value)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// stopCmd represents the stop command
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the octo session running in the current directory",
+	Long: `The stop command gracefully stops the 'octo run' session started in the
+current directory, as listed by 'octo ps'.
+
+octo is sent SIGTERM, so it forwards the signal to the app, runs its
+cleanup (such as stopping the Compose services it started) and exits.
+The command waits up to --timeout for the session to end.`,
+	Args: cobra.NoArgs,
+	RunE: runStop,
+}
+
+func init() {
+	stopCmd.Flags().Duration("timeout", 10*time.Second, "How long to wait for the session to exit")
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	infos, err := ports.ListOctoManagedPorts()
+	if err != nil {
+		return fmt.Errorf("failed to list running projects: %w", err)
+	}
+
+	var sessions []ports.OctoPortInfo
+	for _, info := range infos {
+		if filepath.Clean(info.WorkDir) == filepath.Clean(cwd) {
+			sessions = append(sessions, info)
+		}
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no octo session is running in %s (see 'octo ps')", cwd)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, info := range sessions {
+		if err := syscall.Kill(info.PID, syscall.SIGTERM); err != nil {
+			return fmt.Errorf("failed to stop %s (PID %d): %w", info.Project, info.PID, err)
+		}
+		fmt.Fprintf(out, "⏹️  Stopping %s (PID %d)\n", info.Project, info.PID)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for _, info := range sessions {
+		for ports.IsProcessAlive(info.PID) {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("%s (PID %d) is still running after %s", info.Project, info.PID, timeout)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	fmt.Fprintln(out, "✅ Stopped")
+	return nil
+}
@@ -2,11 +2,13 @@ package analyzer
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -62,7 +64,7 @@ type ProjectInfo struct {
 	RunCommand string
 	// PortConfig contains detected port information
 	PortConfig PortConfig
-	// PackageManager is the detected package manager (npm, pnpm, yarn, bun)
+	// PackageManager is the detected package manager (npm, pnpm, yarn, bun, rush)
 	PackageManager string
 	// SetupCommand is the command to run before the main run command (e.g., build, setup)
 	SetupCommand string
@@ -72,6 +74,20 @@ type ProjectInfo struct {
 	IsMonorepo bool
 	// MonorepoRoot is the root path of the monorepo (if applicable)
 	MonorepoRoot string
+	// Framework is the detected web framework (e.g., Astro), for display purposes
+	Framework string
+	// WorkspaceRunners lists every monorepo task runner configured in the project root (nx, turbo, lerna)
+	WorkspaceRunners []string
+	// WorkspacePackages lists the package names declared by the monorepo config (e.g. rush.json projects)
+	WorkspacePackages []string
+	// Services lists separately runnable dev servers, e.g. an app and its Storybook
+	Services []ServiceInfo
+}
+
+// ServiceInfo is a separately runnable dev server detected in the project
+type ServiceInfo struct {
+	Name       string
+	RunCommand string
 }
 
 // signalFile represents a file that signals a specific project type.
@@ -82,14 +98,18 @@ type signalFile struct {
 
 // Signal files for project detection
 var signalFiles = []signalFile{
+	{"rush.json", "Node"}, // Rush roots often have no package.json of their own
 	{"package.json", "Node"},
 	{"pom.xml", "Java"},
 	{"build.gradle", "Java"},
+	{"build.gradle.kts", "Kotlin"},
 	{"requirements.txt", "Python"},
 	{"pyproject.toml", "Python"},
 	{"go.mod", "Go"},
 	{"Cargo.toml", "Rust"},
 	{"Gemfile", "Ruby"},
+	{"Package.swift", "Swift"},
+	{"Makefile", "Make"}, // Lowest priority: many language projects also ship a Makefile
 }
 
 // Analyze performs a minimal analysis of the provided directory.
@@ -145,7 +165,7 @@ func AnalyzeProjectWithOptions(path string, opts AnalysisOptions) (ProjectInfo,
 	}
 
 	projectInfo := ProjectInfo{
-		Name:           filepath.Base(abs),
+		Name:           InferProjectName(abs),
 		Language:       "Unknown",
 		Version:        "",
 		RunCommand:     "",
@@ -159,11 +179,13 @@ func AnalyzeProjectWithOptions(path string, opts AnalysisOptions) (ProjectInfo,
 			projectInfo.Language = sf.language
 
 			switch sf.filename {
+			case "rush.json":
+				projectInfo = analyzeRushProject(abs, projectInfo, opts)
 			case "package.json":
 				projectInfo = analyzeNodeProject(abs, projectInfo, opts)
 			case "pom.xml":
 				projectInfo = analyzeJavaProject(abs, projectInfo, "maven")
-			case "build.gradle":
+			case "build.gradle", "build.gradle.kts":
 				projectInfo = analyzeJavaProject(abs, projectInfo, "gradle")
 			case "requirements.txt":
 				projectInfo = analyzePythonProject(abs, projectInfo, "requirements", opts)
@@ -172,9 +194,13 @@ func AnalyzeProjectWithOptions(path string, opts AnalysisOptions) (ProjectInfo,
 			case "go.mod":
 				projectInfo = analyzeGoProject(abs, projectInfo)
 			case "Cargo.toml":
-				projectInfo = analyzeRustProject(abs, projectInfo)
+				projectInfo = analyzeRustProject(abs, projectInfo, opts)
 			case "Gemfile":
 				projectInfo = analyzeRubyProject(abs, projectInfo)
+			case "Package.swift":
+				projectInfo = analyzeSwiftProject(abs, projectInfo)
+			case "Makefile":
+				projectInfo = analyzeMakefileProject(abs, projectInfo, opts)
 			}
 
 			// Stop after first match (priority order)
@@ -182,8 +208,16 @@ func AnalyzeProjectWithOptions(path string, opts AnalysisOptions) (ProjectInfo,
 		}
 	}
 
-	// Detect port configuration from the run command
-	projectInfo.PortConfig = DetectPortConfig(projectInfo.RunCommand, projectInfo.Language)
+	// Notebook-only data science projects have no signal file; run them with Jupyter
+	if projectInfo.Language == "Unknown" && hasNotebooks(abs) {
+		projectInfo.Language = "Python"
+		projectInfo = analyzeJupyterProject(abs, projectInfo)
+	}
+
+	// Detect port configuration from the run command (unless the analyzer already found one)
+	if !projectInfo.PortConfig.Detected {
+		projectInfo.PortConfig = DetectPortConfig(projectInfo.RunCommand, projectInfo.Language)
+	}
 
 	// If no project was detected by signal files, try simple project detection
 	if projectInfo.Language == "Unknown" || projectInfo.RunCommand == "" {
@@ -288,6 +322,34 @@ var setupScriptPriority = []ScriptWeight{
 	{"seed", 50},             // Database seeding
 }
 
+// testScriptNames are package.json scripts that run a test runner. They exit once the
+// suite finishes (or watch tests), so they are never picked as the run command.
+var testScriptNames = map[string]bool{
+	"test":       true,
+	"test:watch": true,
+	"jest":       true,
+	"vitest":     true,
+	"mocha":      true,
+}
+
+// isTestScript reports whether a script runs tests rather than the app
+func isTestScript(name string) bool {
+	return testScriptNames[name] || strings.HasPrefix(name, "test:")
+}
+
+// toolingScriptNames are scripts that build or check the code and then exit
+var toolingScriptNames = map[string]bool{
+	"build":     true,
+	"lint":      true,
+	"format":    true,
+	"typecheck": true,
+}
+
+// isToolingScript reports whether a script builds or checks the code rather than running the app
+func isToolingScript(name string) bool {
+	return toolingScriptNames[name] || strings.HasPrefix(name, "build:") || strings.HasPrefix(name, "lint:")
+}
+
 // detectMonorepoConfig checks if the project is a monorepo and returns workspace info
 func detectMonorepoConfig(projectPath string, packageManager string) (bool, string) {
 	// Check for pnpm workspace
@@ -334,6 +396,53 @@ func detectMonorepoConfig(projectPath string, packageManager string) (bool, stri
 	return false, ""
 }
 
+// WorkspaceRunnerConfig maps a monorepo task runner to the config file that marks it
+type WorkspaceRunnerConfig struct {
+	Name       string
+	ConfigFile string
+	RunTask    string // Runs a package.json script in every workspace package (%s is the script)
+}
+
+// WorkspaceRunnerConfigs are the task runners octo recognizes, in default priority order
+var WorkspaceRunnerConfigs = []WorkspaceRunnerConfig{
+	{Name: "nx", ConfigFile: "nx.json", RunTask: "nx run-many -t %s"},
+	{Name: "turbo", ConfigFile: "turbo.json", RunTask: "turbo run %s"},
+	{Name: "lerna", ConfigFile: "lerna.json", RunTask: "lerna run %s"},
+}
+
+// WorkspaceRunCommand rewrites a command that runs a package.json script (e.g. "npm run dev")
+// to run that script through runner instead, e.g. "nx run-many -t dev".
+// Any other command, or an unknown runner, is returned unchanged.
+func WorkspaceRunCommand(runner string, packageManager string, command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return command
+	}
+	script := fields[len(fields)-1]
+	if buildNodeRunCommand(packageManager, script) != command {
+		return command
+	}
+
+	for _, cfg := range WorkspaceRunnerConfigs {
+		if cfg.Name == runner {
+			return fmt.Sprintf(cfg.RunTask, script)
+		}
+	}
+	return command
+}
+
+// DetectWorkspaceRunners returns every task runner configured in the project root.
+// Large repos sometimes carry more than one (e.g. an Nx config next to a Turborepo config).
+func DetectWorkspaceRunners(projectPath string) []string {
+	var runners []string
+	for _, runner := range WorkspaceRunnerConfigs {
+		if _, err := os.Stat(filepath.Join(projectPath, runner.ConfigFile)); err == nil {
+			runners = append(runners, runner.Name)
+		}
+	}
+	return runners
+}
+
 // detectSetupScript finds the best setup script from package.json
 func detectSetupScript(scripts map[string]string, packageManager string) (string, bool) {
 	for _, sw := range setupScriptPriority {
@@ -364,6 +473,8 @@ func analyzeNodeProject(projectPath string, info ProjectInfo, opts AnalysisOptio
 		Engines struct {
 			Node string `json:"node"`
 		} `json:"engines"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
 	}
 
 	if err := json.Unmarshal(data, &pkg); err != nil {
@@ -380,6 +491,7 @@ func analyzeNodeProject(projectPath string, info ProjectInfo, opts AnalysisOptio
 
 	// Detect if this is a monorepo
 	info.IsMonorepo, info.MonorepoRoot = detectMonorepoConfig(projectPath, info.PackageManager)
+	info.WorkspaceRunners = DetectWorkspaceRunners(projectPath)
 
 	// Detect setup script (mandatory pre-run step)
 	if setupCmd, found := detectSetupScript(pkg.Scripts, info.PackageManager); found {
@@ -405,16 +517,21 @@ func analyzeNodeProject(projectPath string, info ProjectInfo, opts AnalysisOptio
 
 	// If no weighted script found, check for any available script
 	if bestScript == "" {
-		// Fallback priority: start > dev > first available
+		// Fallback priority: start > dev > first available (alphabetically, skipping tests and tooling)
 		if _, ok := pkg.Scripts["start"]; ok {
 			bestScript = "start"
 		} else if _, ok := pkg.Scripts["dev"]; ok {
 			bestScript = "dev"
 		} else {
-			// Use first available script if any
+			names := make([]string, 0, len(pkg.Scripts))
 			for name := range pkg.Scripts {
-				bestScript = name
-				break
+				if !isTestScript(name) && !isToolingScript(name) {
+					names = append(names, name)
+				}
+			}
+			if len(names) > 0 {
+				sort.Strings(names)
+				bestScript = names[0]
 			}
 		}
 	}
@@ -427,9 +544,213 @@ func analyzeNodeProject(projectPath string, info ProjectInfo, opts AnalysisOptio
 		info.RunCommand = buildNodeRunCommand(info.PackageManager, "start")
 	}
 
+	// Astro ships its own dev server, which is more reliable than guessing the script
+	if isAstroProject(projectPath) {
+		info.Framework = "Astro"
+		info.RunCommand = nodeBinCommand(projectPath, "astro", "dev")
+		info.PortConfig = PortConfig{
+			Port:      4321,
+			Detected:  true,
+			FlagType:  "framework-default",
+			IsDefault: true,
+		}
+	}
+
+	hasDependency := func(name string) bool {
+		_, inDeps := pkg.Dependencies[name]
+		_, inDevDeps := pkg.DevDependencies[name]
+		return inDeps || inDevDeps
+	}
+	hasDependencies := func(names []string) bool {
+		for _, name := range names {
+			if !hasDependency(name) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Frameworks with their own dev server decide between "dev" and "start" better than script weights
+	isProduction := opts.Environment == "production" || opts.Environment == "prod"
+	if !isProduction && info.Framework == "" {
+		for _, fw := range nodeFrameworks {
+			if !hasDependencies(fw.dependencies) {
+				continue
+			}
+			info.Framework = fw.name
+			info.RunCommand = frameworkDevCommand(projectPath, info.PackageManager, pkg.Scripts, fw.binary, fw.args)
+			info.PortConfig = PortConfig{
+				Port:      fw.port,
+				Detected:  true,
+				FlagType:  "framework-default",
+				IsDefault: true,
+			}
+			break
+		}
+	}
+
+	// SvelteKit runs on Vite in development; adapter-node builds a standalone server for production
+	if isSvelteKitProject(projectPath) && hasDependency("@sveltejs/kit") {
+		info.Framework = "SvelteKit"
+		if isProduction && hasDependency("@sveltejs/adapter-node") {
+			info.RunCommand = "node build/index.js"
+			// adapter-node listens on PORT, defaulting to 3000
+			info.PortConfig = PortConfig{
+				Port:      3000,
+				Detected:  true,
+				FlagType:  "framework-default",
+				IsDefault: true,
+			}
+		} else if !isProduction {
+			if _, ok := pkg.Scripts["dev"]; ok {
+				info.RunCommand = buildNodeRunCommand(info.PackageManager, "dev")
+			} else {
+				info.RunCommand = nodeBinCommand(projectPath, "vite", "dev")
+			}
+			info.PortConfig = PortConfig{
+				Port:      5173,
+				Detected:  true,
+				FlagType:  "framework-default",
+				IsDefault: true,
+			}
+		}
+	}
+
+	// Component libraries are developed in Storybook rather than in an app
+	if !isProduction && (hasDependency("storybook") || hasDependency("@storybook/core")) {
+		storybookCommand := nodeBinCommand(projectPath, "storybook", "dev -p 6006")
+		if _, ok := pkg.Scripts["storybook"]; ok {
+			storybookCommand = buildNodeRunCommand(info.PackageManager, "storybook")
+		}
+
+		if info.Framework != "" || hasDependency("vite") {
+			// An app with stories: keep the app as the run command and offer both as services
+			info.Services = []ServiceInfo{
+				{Name: "app", RunCommand: info.RunCommand},
+				{Name: "storybook", RunCommand: storybookCommand},
+			}
+		} else {
+			info.Framework = "Storybook"
+			info.RunCommand = storybookCommand
+			info.PortConfig = PortConfig{
+				Port:      6006,
+				Detected:  true,
+				FlagType:  "framework-default",
+				IsDefault: true,
+			}
+		}
+	}
+
+	return info
+}
+
+// svelteConfigFiles are the config files that mark a Svelte or SvelteKit project
+var svelteConfigFiles = []string{"svelte.config.js", "svelte.config.mjs", "svelte.config.ts"}
+
+// isSvelteKitProject checks for a Svelte config file in the project root.
+// Plain Svelte projects have one too, so callers also check for @sveltejs/kit.
+func isSvelteKitProject(projectPath string) bool {
+	for _, name := range svelteConfigFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// condaEnvironmentFiles are the files that define a conda environment
+var condaEnvironmentFiles = []string{"environment.yml", "environment.yaml"}
+
+// condaEnvironmentFile returns the conda environment file in the project root, or ""
+func condaEnvironmentFile(projectPath string) string {
+	for _, name := range condaEnvironmentFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasNotebooks checks for Jupyter notebooks in the project root
+func hasNotebooks(projectPath string) bool {
+	matches, _ := filepath.Glob(filepath.Join(projectPath, "*.ipynb"))
+	return len(matches) > 0
+}
+
+// analyzeJupyterProject configures a notebook project to run the Jupyter server.
+// JupyterLab is used when the conda environment installs it.
+func analyzeJupyterProject(projectPath string, info ProjectInfo) ProjectInfo {
+	info.Framework = "Jupyter"
+	info.RunCommand = "jupyter notebook"
+
+	if envFile := condaEnvironmentFile(projectPath); envFile != "" {
+		info.PackageManager = "conda"
+		if data, err := os.ReadFile(filepath.Join(projectPath, envFile)); err == nil &&
+			strings.Contains(strings.ToLower(string(data)), "jupyterlab") {
+			info.RunCommand = "jupyter lab"
+		}
+	}
+
+	info.PortConfig = PortConfig{
+		Port:      8888,
+		Detected:  true,
+		FlagType:  "framework-default",
+		IsDefault: true,
+	}
 	return info
 }
 
+// astroConfigFiles are the config files that mark an Astro project
+var astroConfigFiles = []string{"astro.config.mjs", "astro.config.ts"}
+
+// isAstroProject checks for an Astro config file in the project root
+func isAstroProject(projectPath string) bool {
+	for _, name := range astroConfigFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeFrameworks fingerprints Node frameworks by dependencies (all must be present), checked in order.
+// Each entry names the framework's dev server command and the port it listens on by default.
+var nodeFrameworks = []struct {
+	dependencies []string
+	name         string
+	binary       string
+	args         string
+	port         int
+}{
+	{[]string{"next"}, "Next.js", "next", "dev", 3000},
+	// Remix on Vite has its own dev command, and Vite's port
+	{[]string{"@remix-run/dev", "vite"}, "Remix", "remix", "vite:dev", 5173},
+	{[]string{"@remix-run/dev"}, "Remix", "remix", "dev", 3000},
+	{[]string{"@angular/core"}, "Angular", "ng", "serve", 4200},
+}
+
+// frameworkDevCommand returns the command that starts a framework's dev server.
+// A package script that already runs it is preferred, since it carries the project's own flags.
+func frameworkDevCommand(projectPath string, packageManager string, scripts map[string]string, binary string, args string) string {
+	command := binary + " " + args
+	for _, name := range []string{"dev", "start", "serve"} {
+		if script, ok := scripts[name]; ok && strings.HasPrefix(strings.TrimSpace(script), command) {
+			return buildNodeRunCommand(packageManager, name)
+		}
+	}
+	return nodeBinCommand(projectPath, binary, args)
+}
+
+// nodeBinCommand runs a CLI from node_modules/.bin if it is installed locally,
+// falling back to npx (which downloads it on demand) otherwise
+func nodeBinCommand(projectPath string, binary string, args string) string {
+	localBin := filepath.Join("node_modules", ".bin", binary)
+	if _, err := os.Stat(filepath.Join(projectPath, localBin)); err == nil {
+		return "./" + localBin + " " + args
+	}
+	return "npx " + binary + " " + args
+}
+
 // analyzeJavaProject extracts info for Java projects
 func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string) ProjectInfo {
 	switch buildTool {
@@ -437,6 +758,8 @@ func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string)
 		// Try to detect Java version and Spring Boot from pom.xml
 		pomPath := filepath.Join(projectPath, "pom.xml")
 		isSpringBoot := false
+		isMicronaut := false
+		isQuarkus := false
 		if data, err := os.ReadFile(pomPath); err == nil {
 			content := string(data)
 			// Simple version detection (look for java.version property)
@@ -451,9 +774,22 @@ func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string)
 			   contains(content, "spring-boot-maven-plugin") {
 				isSpringBoot = true
 			}
+			isMicronaut = contains(content, "micronaut-runtime") || contains(content, "micronaut-maven-plugin")
+			isQuarkus = contains(content, "quarkus-maven-plugin")
 		}
-		// Set run command based on Spring Boot detection
-		if isSpringBoot {
+		// Prefer the Maven wrapper so the project's pinned Maven version is used
+		mvn := "mvn"
+		if _, err := os.Stat(filepath.Join(projectPath, "mvnw")); err == nil {
+			mvn = "./mvnw"
+		}
+		// Set run command based on framework detection
+		if isQuarkus {
+			info.Framework = "Quarkus"
+			info.RunCommand = mvn + " quarkus:dev"
+		} else if isMicronaut {
+			info.Framework = "Micronaut"
+			info.RunCommand = mvn + " mn:run"
+		} else if isSpringBoot {
 			info.RunCommand = "mvn spring-boot:run"
 		} else {
 			info.RunCommand = "mvn package && java -jar target/*.jar"
@@ -466,9 +802,14 @@ func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string)
 			hasGradlew = false
 		}
 		
-		// Try to detect Spring Boot from build.gradle
+		// Try to detect Spring Boot from build.gradle (or build.gradle.kts for Kotlin DSL)
 		buildGradlePath := filepath.Join(projectPath, "build.gradle")
+		if _, err := os.Stat(buildGradlePath); os.IsNotExist(err) {
+			buildGradlePath = filepath.Join(projectPath, "build.gradle.kts")
+		}
 		isSpringBoot := false
+		isMicronaut := false
+		isQuarkus := false
 		if data, err := os.ReadFile(buildGradlePath); err == nil {
 			content := string(data)
 			// Detect Spring Boot indicators
@@ -476,10 +817,24 @@ func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string)
 			   contains(content, "spring-boot") {
 				isSpringBoot = true
 			}
+			isMicronaut = contains(content, "io.micronaut")
+			isQuarkus = contains(content, "io.quarkus")
+		}
+
+		gradle := "gradle"
+		if hasGradlew {
+			gradle = "./gradlew"
 		}
 		
-		// Set run command based on Spring Boot detection and wrapper presence
-		if isSpringBoot {
+		// Set run command based on framework detection and wrapper presence
+		if isQuarkus {
+			info.Framework = "Quarkus"
+			info.RunCommand = gradle + " quarkusDev"
+		} else if isMicronaut {
+			// The Micronaut Gradle plugin applies the application plugin, so `run` starts the server
+			info.Framework = "Micronaut"
+			info.RunCommand = gradle + " run"
+		} else if isSpringBoot {
 			if hasGradlew {
 				info.RunCommand = "./gradlew bootRun"
 			} else {
@@ -494,6 +849,17 @@ func analyzeJavaProject(projectPath string, info ProjectInfo, buildTool string)
 		}
 	}
 
+	// Spring Boot reads server.port from its application config, which takes
+	// precedence over the 8080 default implied by the run command
+	if port, ok := detectSpringBootPort(projectPath); ok {
+		info.PortConfig = PortConfig{
+			Port:      port,
+			Detected:  true,
+			FlagType:  "server.port",
+			IsDefault: false,
+		}
+	}
+
 	return info
 }
 
@@ -527,6 +893,10 @@ func getPythonEntryPointWeights(env string) []ScriptWeight {
 
 // analyzePythonProject extracts info for Python projects with context-aware selection
 func analyzePythonProject(projectPath string, info ProjectInfo, configType string, opts AnalysisOptions) ProjectInfo {
+	if condaEnvironmentFile(projectPath) != "" {
+		info.PackageManager = "conda"
+	}
+
 	switch configType {
 	case "requirements":
 		// Get weighted entry points based on environment
@@ -627,12 +997,68 @@ func analyzeGoProject(projectPath string, info ProjectInfo) ProjectInfo {
 	} else {
 		info.RunCommand = "go run ."
 	}
+
+	// Prefer the air live-reload tool when the project is configured for it
+	for _, name := range airConfigFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			info.RunCommand = "air"
+			if name != ".air.toml" {
+				// air only picks up .air.toml by default
+				info.RunCommand = "air -c " + name
+			}
+			break
+		}
+	}
 	
 	return info
 }
 
+// airConfigFiles are the config files of the air live-reload tool, in priority order
+var airConfigFiles = []string{".air.toml", ".air.conf"}
+
+// airDefaultBin is where air writes the built binary unless [build] bin says otherwise
+const airDefaultBin = "./tmp/main"
+
+// airBinPattern matches the bin setting, e.g. bin = "./tmp/main"
+var airBinPattern = regexp.MustCompile(`^\s*bin\s*=\s*["']([^"']+)["']`)
+
+// AirBinPath returns the binary path air builds to, from the [build] section of its config
+func AirBinPath(projectPath string) string {
+	for _, name := range airConfigFiles {
+		data, err := os.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+
+		inBuild := false
+		for _, line := range strings.Split(string(data), "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "[") {
+				inBuild = trimmed == "[build]"
+				continue
+			}
+			if !inBuild {
+				continue
+			}
+			if matches := airBinPattern.FindStringSubmatch(line); len(matches) == 2 {
+				bin := matches[1]
+				if !strings.HasPrefix(bin, "./") && !filepath.IsAbs(bin) {
+					bin = "./" + bin
+				}
+				return bin
+			}
+		}
+		return airDefaultBin
+	}
+	return airDefaultBin
+}
+
+// rustWebFrameworks lists Cargo dependencies that indicate an HTTP server
+var rustWebFrameworks = []string{"actix-web", "axum", "warp"}
+
 // analyzeRustProject extracts info for Rust projects
-func analyzeRustProject(projectPath string, info ProjectInfo) ProjectInfo {
+func analyzeRustProject(projectPath string, info ProjectInfo, opts AnalysisOptions) ProjectInfo {
+	var deps map[string]bool
 	cargoTomlPath := filepath.Join(projectPath, "Cargo.toml")
 	if data, err := os.ReadFile(cargoTomlPath); err == nil {
 		content := string(data)
@@ -647,25 +1073,192 @@ func analyzeRustProject(projectPath string, info ProjectInfo) ProjectInfo {
 		if contains(content, "version = ") {
 			info.Version = extractTomlStringValue(content, "version = ")
 		}
+		deps = extractCargoDependencies(content)
 	}
-	
-	// Default Rust run command
-	info.RunCommand = "cargo run"
-	
+
+	isWebService := false
+	for _, framework := range rustWebFrameworks {
+		if deps[framework] {
+			isWebService = true
+			break
+		}
+	}
+
+	// Web frameworks (actix-web, axum, warp) conventionally listen on 8080
+	if isWebService {
+		info.PortConfig = PortConfig{
+			Port:      8080,
+			Detected:  true,
+			FlagType:  "framework-default",
+			IsDefault: true,
+		}
+	}
+
+	isProduction := opts.Environment == "production" || opts.Environment == "prod"
+	switch {
+	case isWebService && isProduction:
+		info.RunCommand = "cargo run --release"
+	case deps["tokio"] && !isProduction:
+		// Async runtimes are much easier to debug with logging enabled
+		info.RunCommand = "RUST_LOG=info cargo run"
+	default:
+		info.RunCommand = "cargo run"
+	}
+
+	return info
+}
+
+// extractCargoDependencies returns the crate names listed under [dependencies]
+// (including [dependencies.<name>] tables) in a Cargo.toml file
+func extractCargoDependencies(content string) map[string]bool {
+	deps := make(map[string]bool)
+	inDeps := false
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			section := strings.Trim(line, "[] ")
+			inDeps = section == "dependencies"
+			if strings.HasPrefix(section, "dependencies.") {
+				deps[strings.TrimPrefix(section, "dependencies.")] = true
+			}
+			continue
+		}
+
+		if inDeps {
+			if idx := strings.Index(line, "="); idx > 0 {
+				name := strings.Trim(strings.TrimSpace(line[:idx]), `"`)
+				deps[name] = true
+			}
+		}
+	}
+
+	return deps
+}
+
+// swiftExecutablePattern matches executable products declared in Package.swift,
+// e.g. .executable(name: "App", targets: ["App"])
+var swiftExecutablePattern = regexp.MustCompile(`\.executable\(\s*name:\s*"([^"]+)"`)
+
+// analyzeSwiftProject extracts info for Swift Package Manager projects
+func analyzeSwiftProject(projectPath string, info ProjectInfo) ProjectInfo {
+	data, err := os.ReadFile(filepath.Join(projectPath, "Package.swift"))
+	if err != nil {
+		info.RunCommand = "swift run"
+		return info
+	}
+	content := string(data)
+
+	// Package(name: "MyApp", ...)
+	if contains(content, "name: \"") {
+		if pkgName := extractBetween(content, "name: \"", "\""); pkgName != "" {
+			info.Name = pkgName
+		}
+	}
+
+	// swift-tools-version:5.9
+	if contains(content, "swift-tools-version:") {
+		info.Version = trimWhitespace(extractBetween(content, "swift-tools-version:", "\n"))
+	}
+
+	// Executables are declared in PackageDescription.products (or as executableTarget)
+	executables := swiftExecutablePattern.FindAllStringSubmatch(content, -1)
+	switch {
+	case len(executables) == 1:
+		info.RunCommand = "swift run " + executables[0][1]
+	case len(executables) > 1 || contains(content, "executableTarget("):
+		info.RunCommand = "swift run"
+	default:
+		// Library-only package: build it rather than suggesting `swift test`
+		info.RunCommand = "swift build"
+	}
+
+	return info
+}
+
+// makeTargetPattern matches target definitions like "run:" or "dev: build",
+// skipping variable assignments such as "CC := gcc"
+var makeTargetPattern = regexp.MustCompile(`(?m)^([A-Za-z0-9_.-]+)\s*:([^=]|$)`)
+
+// getMakeTargetWeights returns weighted Makefile targets based on environment
+// Higher weight = higher priority
+func getMakeTargetWeights(env string) []ScriptWeight {
+	if env == "development" || env == "dev" {
+		return []ScriptWeight{
+			{"dev", 100},
+			{"run", 90},
+			{"serve", 80},
+			{"start", 70},
+		}
+	}
+
+	return []ScriptWeight{
+		{"start", 100},
+		{"serve", 95},
+		{"run", 90},
+		{"dev", 30},
+	}
+}
+
+// analyzeMakefileProject extracts info for projects driven by a Makefile
+func analyzeMakefileProject(projectPath string, info ProjectInfo, opts AnalysisOptions) ProjectInfo {
+	// Plain `make` runs the default target
+	info.RunCommand = "make"
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "Makefile"))
+	if err != nil {
+		return info
+	}
+
+	targets := make(map[string]bool)
+	for _, match := range makeTargetPattern.FindAllStringSubmatch(string(data), -1) {
+		targets[match[1]] = true
+	}
+
+	bestWeight := -1
+	for _, tw := range getMakeTargetWeights(opts.Environment) {
+		if targets[tw.Name] && tw.Weight > bestWeight {
+			bestWeight = tw.Weight
+			info.RunCommand = "make " + tw.Name
+		}
+	}
+
 	return info
 }
 
 // analyzeRubyProject extracts info for Ruby projects
 func analyzeRubyProject(projectPath string, info ProjectInfo) ProjectInfo {
+	// Rails and Hanami apps also ship a config.ru, so check for the frameworks first
+	application, appErr := os.ReadFile(filepath.Join(projectPath, "config", "application.rb"))
+	appRb, appRbErr := os.ReadFile(filepath.Join(projectPath, "app.rb"))
+
 	// Check for common Ruby frameworks and entry points
-	if _, err := os.Stat(filepath.Join(projectPath, "config.ru")); err == nil {
-		// Rack application
-		info.RunCommand = "bundle exec rackup"
-	} else if _, err := os.Stat(filepath.Join(projectPath, "config", "application.rb")); err == nil {
+	if appErr == nil && contains(string(application), "Hanami") {
+		info.RunCommand = "bundle exec hanami server"
+		info.Framework = "Hanami"
+	} else if appErr == nil {
 		// Rails application
 		info.RunCommand = "bundle exec rails server"
-	} else if _, err := os.Stat(filepath.Join(projectPath, "app.rb")); err == nil {
-		// Sinatra or simple Ruby app
+		info.Framework = "Rails"
+	} else if appRbErr == nil && isSinatraApp(string(appRb)) {
+		// Sinatra listens on 4567 unless told otherwise
+		info.RunCommand = "bundle exec ruby app.rb"
+		info.Framework = "Sinatra"
+		info.PortConfig = PortConfig{
+			Port:      4567,
+			Detected:  true,
+			FlagType:  "default",
+			IsDefault: true,
+		}
+	} else if _, err := os.Stat(filepath.Join(projectPath, "config.ru")); err == nil {
+		// Rack application
+		info.RunCommand = "bundle exec rackup"
+	} else if appRbErr == nil {
+		// Simple Ruby app
 		info.RunCommand = "bundle exec ruby app.rb"
 	} else if _, err := os.Stat(filepath.Join(projectPath, "main.rb")); err == nil {
 		info.RunCommand = "bundle exec ruby main.rb"
@@ -685,6 +1278,12 @@ func analyzeRubyProject(projectPath string, info ProjectInfo) ProjectInfo {
 	return info
 }
 
+// isSinatraApp reports whether a Ruby source file loads Sinatra (classic or modular style)
+func isSinatraApp(source string) bool {
+	return contains(source, "require 'sinatra'") || contains(source, `require "sinatra"`) ||
+		contains(source, "require 'sinatra/base'") || contains(source, `require "sinatra/base"`)
+}
+
 // Add these to your signalFiles or as a separate extension check
 func DetectSimpleProject(abs string) (ProjectInfo, error) {
 	files, err := os.ReadDir(abs)
@@ -736,7 +1335,7 @@ func DetectSimpleProject(abs string) (ProjectInfo, error) {
 			}
 		}
 		return ProjectInfo{
-			Name:       filepath.Base(abs),
+			Name:       InferProjectName(abs),
 			Language:   "HTML",
 			RunCommand: GetBrowserOpenCommand(targetFile),
 		}, nil
@@ -769,7 +1368,7 @@ func DetectSimpleProject(abs string) (ProjectInfo, error) {
 		}
 
 		return ProjectInfo{
-			Name:       filepath.Base(abs),
+			Name:       InferProjectName(abs),
 			Language:   "Python",
 			RunCommand: runCmd,
 		}, nil
@@ -989,6 +1588,7 @@ var defaultPortsByLanguage = map[string]int{
 	"Node":   3000,
 	"Python": 5000, // Flask default
 	"Java":   8080, // Spring Boot default
+	"Kotlin": 8080, // Spring Boot / Ktor default
 	"Go":     8080,
 	"Ruby":   3000, // Rails default
 	"Rust":   8080,
@@ -1003,9 +1603,54 @@ var defaultPortsByCommand = map[string]int{
 	"flask run":                   5000,
 	"python manage.py runserver": 8000, // Django
 	"rails server":                3000,
+	"hanami server":               2300,
+	"rackup":                      9292,
 	"mvn spring-boot:run":        8080,
 	"./gradlew bootRun":           8080,
 	"gradle bootRun":              8080,
+	"quarkus:dev":                 8080, // Quarkus (Maven)
+	"quarkusDev":                  8080, // Quarkus (Gradle)
+	"mn:run":                      8080, // Micronaut (Maven)
+	"jupyter notebook":            8888,
+	"jupyter lab":                 8888,
+	"storybook dev":               6006,
+}
+
+// springBootPortPatterns match server.port in application.properties and application.yml
+var springBootPortPatterns = []*regexp.Regexp{
+	// server.port=8081 (properties) or server.port: 8081 (flat yaml)
+	regexp.MustCompile(`(?m)^\s*server\.port\s*[=:]\s*["']?(\d+)`),
+	// server:\n  port: 8081 (nested yaml)
+	regexp.MustCompile(`(?m)^server:\s*\n(?:[ \t]+.*\n)*?[ \t]+port:\s*["']?(\d+)`),
+}
+
+// springBootConfigFiles lists the Spring Boot config files checked for server.port, in priority order
+var springBootConfigFiles = []string{
+	"application.properties",
+	"application.yml",
+	"application.yaml",
+}
+
+// detectSpringBootPort reads server.port from src/main/resources/application.{properties,yml}
+func detectSpringBootPort(projectPath string) (int, bool) {
+	resourcesDir := filepath.Join(projectPath, "src", "main", "resources")
+	for _, name := range springBootConfigFiles {
+		data, err := os.ReadFile(filepath.Join(resourcesDir, name))
+		if err != nil {
+			continue
+		}
+		for _, pattern := range springBootPortPatterns {
+			matches := pattern.FindStringSubmatch(string(data))
+			if len(matches) < 2 {
+				continue
+			}
+			port, err := strconv.Atoi(matches[1])
+			if err == nil && port > 0 && port < 65536 {
+				return port, true
+			}
+		}
+	}
+	return 0, false
 }
 
 // DetectPortConfig scans a run command for port configuration
@@ -0,0 +1,231 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeNodeProject creates a project directory with the given package.json
+func writeNodeProject(t *testing.T, packageJSON string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(packageJSON), 0644); err != nil {
+		t.Fatalf("Failed to write package.json: %v", err)
+	}
+	return dir
+}
+
+func analyzeNode(t *testing.T, packageJSON string, environment string) ProjectInfo {
+	t.Helper()
+	info, err := AnalyzeProjectWithOptions(writeNodeProject(t, packageJSON), AnalysisOptions{Environment: environment})
+	if err != nil {
+		t.Fatalf("AnalyzeProjectWithOptions returned error: %v", err)
+	}
+	return info
+}
+
+func TestAnalyzeStorybookOnly(t *testing.T) {
+	info := analyzeNode(t, `{
+		"scripts": {"storybook": "storybook dev -p 6006", "build-storybook": "storybook build"},
+		"devDependencies": {"storybook": "^8.0.0", "@storybook/react": "^8.0.0"}
+	}`, "development")
+
+	if info.Framework != "Storybook" || info.RunCommand != "npm run storybook" {
+		t.Errorf("Framework, RunCommand = %q, %q; want Storybook, npm run storybook", info.Framework, info.RunCommand)
+	}
+	if info.PortConfig.Port != 6006 {
+		t.Errorf("PortConfig.Port = %d, want 6006", info.PortConfig.Port)
+	}
+	if info.Services != nil {
+		t.Errorf("Services = %+v, want none for a Storybook-only project", info.Services)
+	}
+}
+
+func TestAnalyzeStorybookWithApp(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		framework   string
+		app         string
+	}{
+		{
+			name: "Next.js",
+			packageJSON: `{
+				"scripts": {"dev": "next dev", "start": "next start"},
+				"dependencies": {"next": "14.0.0"},
+				"devDependencies": {"@storybook/core": "^7.0.0"}
+			}`,
+			framework: "Next.js",
+			app:       "npm run dev",
+		},
+		{
+			name: "Vite",
+			packageJSON: `{
+				"scripts": {"dev": "vite", "storybook": "storybook dev -p 6006"},
+				"devDependencies": {"vite": "^5.0.0", "storybook": "^8.0.0"}
+			}`,
+			framework: "",
+			app:       "npm run dev",
+		},
+	}
+
+	for _, tt := range tests {
+		info := analyzeNode(t, tt.packageJSON, "development")
+
+		// The app stays the run command; Storybook is offered next to it
+		if info.Framework != tt.framework || info.RunCommand != tt.app {
+			t.Errorf("%s: Framework, RunCommand = %q, %q; want %q, %q", tt.name, info.Framework, info.RunCommand, tt.framework, tt.app)
+		}
+		if len(info.Services) != 2 {
+			t.Fatalf("%s: Services = %+v, want app and storybook", tt.name, info.Services)
+		}
+		if info.Services[0] != (ServiceInfo{Name: "app", RunCommand: tt.app}) {
+			t.Errorf("%s: Services[0] = %+v, want the app", tt.name, info.Services[0])
+		}
+		if info.Services[1].Name != "storybook" {
+			t.Errorf("%s: Services[1] = %+v, want storybook", tt.name, info.Services[1])
+		}
+	}
+}
+
+func TestAnalyzeStorybookProduction(t *testing.T) {
+	info := analyzeNode(t, `{
+		"scripts": {"start": "node server.js", "storybook": "storybook dev -p 6006"},
+		"devDependencies": {"storybook": "^8.0.0"}
+	}`, "production")
+
+	if info.Framework == "Storybook" || info.RunCommand != "npm start" {
+		t.Errorf("Framework, RunCommand = %q, %q; want the start script in production", info.Framework, info.RunCommand)
+	}
+	if info.Services != nil {
+		t.Errorf("Services = %+v, want none in production", info.Services)
+	}
+}
+
+func TestIsTestScript(t *testing.T) {
+	tests := map[string]bool{
+		"test":       true,
+		"test:watch": true,
+		"test:e2e":   true,
+		"jest":       true,
+		"vitest":     true,
+		"mocha":      true,
+		"dev":        false,
+		"start":      false,
+		"testserver": false,
+	}
+	for name, want := range tests {
+		if got := isTestScript(name); got != want {
+			t.Errorf("isTestScript(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestAnalyzeNodeScriptFallback(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		want        string
+	}{
+		{
+			name:        "tests, build and lint are skipped",
+			packageJSON: `{"scripts": {"build": "tsc", "lint": "eslint .", "jest": "jest", "test": "jest", "worker": "node worker.js"}}`,
+			want:        "npm run worker",
+		},
+		{
+			name:        "build:prod and lint:fix are skipped",
+			packageJSON: `{"scripts": {"build:prod": "tsc", "lint:fix": "eslint --fix .", "preview": "vite preview"}}`,
+			want:        "npm run preview",
+		},
+		{
+			name:        "only tests and tooling falls back to start",
+			packageJSON: `{"scripts": {"build": "tsc", "test": "vitest"}}`,
+			want:        "npm start",
+		},
+	}
+
+	for _, tt := range tests {
+		info := analyzeNode(t, tt.packageJSON, "development")
+		if info.RunCommand != tt.want {
+			t.Errorf("%s: RunCommand = %q, want %q", tt.name, info.RunCommand, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeNodeFrameworks(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		framework   string
+		run         string
+		port        int
+	}{
+		{
+			name:        "Next.js",
+			packageJSON: `{"scripts": {"start": "next start"}, "dependencies": {"next": "14.0.0"}}`,
+			framework:   "Next.js",
+			run:         "npx next dev",
+			port:        3000,
+		},
+		{
+			name:        "classic Remix",
+			packageJSON: `{"scripts": {"dev": "remix dev"}, "devDependencies": {"@remix-run/dev": "^2.0.0"}}`,
+			framework:   "Remix",
+			run:         "npm run dev",
+			port:        3000,
+		},
+		{
+			name:        "Remix on Vite",
+			packageJSON: `{"scripts": {"dev": "remix vite:dev"}, "devDependencies": {"@remix-run/dev": "^2.8.0", "vite": "^5.0.0"}}`,
+			framework:   "Remix",
+			run:         "npm run dev",
+			port:        5173,
+		},
+		{
+			name:        "Remix on Vite without a dev script",
+			packageJSON: `{"scripts": {"start": "remix-serve ./build/server/index.js"}, "devDependencies": {"@remix-run/dev": "^2.8.0", "vite": "^5.0.0"}}`,
+			framework:   "Remix",
+			run:         "npx remix vite:dev",
+			port:        5173,
+		},
+		{
+			name:        "Angular",
+			packageJSON: `{"scripts": {"start": "ng serve"}, "dependencies": {"@angular/core": "^17.0.0"}}`,
+			framework:   "Angular",
+			run:         "npm start",
+			port:        4200,
+		},
+	}
+
+	for _, tt := range tests {
+		info := analyzeNode(t, tt.packageJSON, "development")
+		if info.Framework != tt.framework || info.RunCommand != tt.run || info.PortConfig.Port != tt.port {
+			t.Errorf("%s: got %q, %q, port %d; want %q, %q, port %d",
+				tt.name, info.Framework, info.RunCommand, info.PortConfig.Port, tt.framework, tt.run, tt.port)
+		}
+	}
+}
+
+func TestWorkspaceRunCommand(t *testing.T) {
+	tests := []struct {
+		runner         string
+		packageManager string
+		command        string
+		want           string
+	}{
+		{"nx", "npm", "npm run dev", "nx run-many -t dev"},
+		{"turbo", "pnpm", "pnpm run dev", "turbo run dev"},
+		{"lerna", "yarn", "yarn dev", "lerna run dev"},
+		{"lerna", "npm", "npm start", "lerna run start"},
+		// Commands that do not run a package script are kept
+		{"nx", "npm", "next dev", "next dev"},
+		{"nx", "npm", "PORT=3000 npm run dev", "PORT=3000 npm run dev"},
+		{"rush", "npm", "npm run dev", "npm run dev"},
+	}
+	for _, tt := range tests {
+		if got := WorkspaceRunCommand(tt.runner, tt.packageManager, tt.command); got != tt.want {
+			t.Errorf("WorkspaceRunCommand(%q, %q, %q) = %q, want %q", tt.runner, tt.packageManager, tt.command, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// genericDirNames are directory names that say nothing about the project they contain
+var genericDirNames = map[string]bool{
+	"src": true, "app": true, "apps": true, "code": true, "project": true, "projects": true,
+	"repo": true, "workspace": true, "work": true, "main": true, "dev": true,
+	"web": true, "www": true, "site": true, "frontend": true, "backend": true,
+	"server": true, "client": true, "api": true,
+}
+
+// InferProjectName returns the project name for dir. It is the directory name unless that is
+// generic (src, app, ...), in which case the repository name from the origin remote is used.
+func InferProjectName(dir string) string {
+	name := filepath.Base(dir)
+	if !genericDirNames[strings.ToLower(name)] {
+		return name
+	}
+
+	out, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return name
+	}
+	if repo := RepoNameFromRemote(strings.TrimSpace(string(out))); repo != "" {
+		return repo
+	}
+	return name
+}
+
+// RepoNameFromRemote extracts the repository name from a git remote URL such as
+// https://github.com/owner/repo.git, git@gitlab.com:group/sub/repo.git or ssh://host/owner/repo
+func RepoNameFromRemote(remote string) string {
+	remote = strings.TrimSuffix(strings.TrimRight(remote, "/"), ".git")
+	if idx := strings.LastIndexAny(remote, "/:"); idx != -1 {
+		remote = remote[idx+1:]
+	}
+	return remote
+}
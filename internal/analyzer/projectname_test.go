@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoNameFromRemote(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/owner/shop.git":          "shop",
+		"https://github.com/owner/shop":              "shop",
+		"https://github.com/owner/shop/":             "shop",
+		"git@github.com:owner/shop.git":              "shop",
+		"git@github.com:owner/shop":                  "shop",
+		"git@gitlab.com:group/sub/shop.git":          "shop",
+		"ssh://git@host.example.com:2222/owner/shop": "shop",
+		"ssh://git@host.example.com/owner/shop.git":  "shop",
+		"/srv/git/shop.git":                          "shop",
+		"shop.git":                                   "shop",
+		"":                                           "",
+	}
+	for remote, want := range tests {
+		if got := RepoNameFromRemote(remote); got != want {
+			t.Errorf("RepoNameFromRemote(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
+
+func TestInferProjectNameKeepsSpecificNames(t *testing.T) {
+	if got := InferProjectName("/home/me/shop"); got != "shop" {
+		t.Errorf("InferProjectName = %q, want the directory name", got)
+	}
+	// A generic directory outside a git repository keeps its own name
+	dir := filepath.Join(t.TempDir(), "app")
+	if got := InferProjectName(dir); got != "app" {
+		t.Errorf("InferProjectName = %q, want app without a remote", got)
+	}
+}
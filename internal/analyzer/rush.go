@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// rushConfig is the subset of rush.json octo reads
+type rushConfig struct {
+	NodeSupportedVersionRange string `json:"nodeSupportedVersionRange"`
+	Projects                  []struct {
+		PackageName   string `json:"packageName"`
+		ProjectFolder string `json:"projectFolder"`
+	} `json:"projects"`
+}
+
+// analyzeRushProject extracts info from a Microsoft Rush monorepo (rush.json)
+func analyzeRushProject(projectPath string, info ProjectInfo, opts AnalysisOptions) ProjectInfo {
+	info.Language = "Node"
+	info.PackageManager = "rush"
+	info.IsMonorepo = true
+	info.MonorepoRoot = projectPath
+	info.WorkspaceRunners = DetectWorkspaceRunners(projectPath)
+
+	var config rushConfig
+	if data, err := os.ReadFile(filepath.Join(projectPath, "rush.json")); err == nil {
+		_ = json.Unmarshal(stripJSONComments(data), &config)
+	}
+	if config.NodeSupportedVersionRange != "" {
+		info.Version = config.NodeSupportedVersionRange
+	}
+
+	for name := range RushProjects(projectPath) {
+		info.WorkspacePackages = append(info.WorkspacePackages, name)
+	}
+	sort.Strings(info.WorkspacePackages)
+
+	// "rush start" is not built in; prefer a custom command the repo defines
+	command := "start"
+	commands := rushCustomCommands(projectPath)
+	bestWeight := -1
+	for _, sw := range getNodeScriptWeights(opts.Environment) {
+		if commands[sw.Name] && sw.Weight > bestWeight {
+			bestWeight = sw.Weight
+			command = sw.Name
+		}
+	}
+	info.RunCommand = "rush build && rush " + command
+
+	return info
+}
+
+// RushProjects maps each project listed in rush.json to its directory.
+// rush.json allows comments, so they are stripped before decoding.
+func RushProjects(projectPath string) map[string]string {
+	projects := make(map[string]string)
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "rush.json"))
+	if err != nil {
+		return projects
+	}
+
+	var config rushConfig
+	if err := json.Unmarshal(stripJSONComments(data), &config); err != nil {
+		return projects
+	}
+
+	for _, project := range config.Projects {
+		if project.PackageName == "" || project.ProjectFolder == "" {
+			continue
+		}
+		projects[project.PackageName] = filepath.Join(projectPath, project.ProjectFolder)
+	}
+	return projects
+}
+
+// rushCustomCommands returns the commands declared in common/config/rush/command-line.json
+func rushCustomCommands(projectPath string) map[string]bool {
+	commands := make(map[string]bool)
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "common", "config", "rush", "command-line.json"))
+	if err != nil {
+		return commands
+	}
+
+	var config struct {
+		Commands []struct {
+			Name string `json:"name"`
+		} `json:"commands"`
+	}
+	if err := json.Unmarshal(stripJSONComments(data), &config); err != nil {
+		return commands
+	}
+
+	for _, command := range config.Commands {
+		if command.Name != "" {
+			commands[command.Name] = true
+		}
+	}
+	return commands
+}
+
+// stripJSONComments removes // and /* */ comments outside of string literals
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			out = append(out, c)
+			continue
+		}
+		if c == '/' && i+1 < len(data) && data[i+1] == '/' {
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+			continue
+		}
+		if c == '/' && i+1 < len(data) && data[i+1] == '*' {
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -1,47 +1,454 @@
 package blueprint
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/harshul/octo-cli/internal/analyzer"
 	"gopkg.in/yaml.v3"
 )
 
+// ConcurrencyStrategy is the thermal mode that decides how many workers octo uses
+type ConcurrencyStrategy string
+
+const (
+	// StrategyAuto detects the hardware and picks a concurrency that keeps it quiet
+	StrategyAuto ConcurrencyStrategy = "auto"
+	// StrategyPerformance uses every core regardless of thermals
+	StrategyPerformance ConcurrencyStrategy = "performance"
+	// StrategyBalanced uses three quarters of the cores, leaving headroom for the rest of the system
+	StrategyBalanced ConcurrencyStrategy = "balanced"
+	// StrategyCool prioritizes low temperatures over speed (the efficiency cores on Apple Silicon, otherwise half the cores)
+	StrategyCool ConcurrencyStrategy = "cool"
+	// StrategyManual uses concurrency, batch_size and cool_down_ms exactly as configured
+	StrategyManual ConcurrencyStrategy = "manual"
+)
+
+// ConcurrencyStrategies lists every valid strategy, in the order shown in help text
+var ConcurrencyStrategies = []ConcurrencyStrategy{
+	StrategyAuto,
+	StrategyPerformance,
+	StrategyBalanced,
+	StrategyCool,
+	StrategyManual,
+}
+
+// ParseConcurrencyStrategy converts a mode name to a ConcurrencyStrategy.
+// An empty string means StrategyAuto.
+func ParseConcurrencyStrategy(s string) (ConcurrencyStrategy, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return StrategyAuto, nil
+	}
+	for _, strategy := range ConcurrencyStrategies {
+		if s == string(strategy) {
+			return strategy, nil
+		}
+	}
+
+	names := make([]string, len(ConcurrencyStrategies))
+	for i, strategy := range ConcurrencyStrategies {
+		names[i] = string(strategy)
+	}
+	return "", fmt.Errorf("invalid thermal mode %q (expected one of: %s)", s, strings.Join(names, ", "))
+}
+
+// UnmarshalYAML rejects unknown thermal modes when reading .octo.yaml
+func (s *ConcurrencyStrategy) UnmarshalYAML(value *yaml.Node) error {
+	strategy, err := ParseConcurrencyStrategy(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*s = strategy
+	return nil
+}
+
 // ThermalConfig holds thermal and resource management settings
 type ThermalConfig struct {
+	// Mode is the concurrency strategy (empty = auto)
+	Mode ConcurrencyStrategy `yaml:"mode,omitempty" description:"Thermal mode: auto (detect hardware), performance (all cores), balanced (3/4 of cores), cool (efficiency cores on Apple Silicon, otherwise half the cores), or manual (use concurrency, batch_size and cool_down_ms as-is)" enum:"auto,performance,balanced,cool,manual"`
 	// Concurrency is the maximum number of concurrent operations (0 = auto-detect)
-	Concurrency int `yaml:"concurrency,omitempty"`
+	Concurrency int `yaml:"concurrency,omitempty" description:"Maximum number of concurrent operations (0 = auto-detect)"`
 	// BatchSize is the number of projects to process in each batch (0 = auto-detect)
-	BatchSize int `yaml:"batch_size,omitempty"`
+	BatchSize int `yaml:"batch_size,omitempty" description:"Number of projects to process in each batch (0 = auto-detect)"`
 	// CoolDownMs is the delay between batches in milliseconds (0 = use default)
-	CoolDownMs int `yaml:"cool_down_ms,omitempty"`
-	// Mode is the thermal mode ("auto", "cool", "performance")
-	// - "auto": Automatically detect and adjust based on hardware
-	// - "cool": Prioritize low temperatures over speed
-	// - "performance": Use maximum resources regardless of thermals
-	Mode string `yaml:"mode,omitempty"`
+	CoolDownMs int `yaml:"cool_down_ms,omitempty" description:"Delay between batches in milliseconds (0 = use default)"`
 }
 
 // Blueprint is a configuration derived from project analysis.
+// The description tags are used to generate the JSON Schema printed by `octo schema`.
 type Blueprint struct {
-	Name           string        `yaml:"name"`
-	Language       string        `yaml:"language,omitempty"`
-	Version        string        `yaml:"version,omitempty"`
-	RunCommand     string        `yaml:"run,omitempty"`
-	SetupCommand   string        `yaml:"setup,omitempty"`
-	SetupRequired  bool          `yaml:"setup_required,omitempty"`
-	PackageManager string        `yaml:"package_manager,omitempty"`
-	IsMonorepo     bool          `yaml:"is_monorepo,omitempty"`
-	MonorepoRoot   string        `yaml:"monorepo_root,omitempty"`
-	EnvVars        []EnvVar      `yaml:"env_vars,omitempty"`
-	Thermal        ThermalConfig `yaml:"thermal,omitempty"`
+	Name                string              `yaml:"name" description:"Project name"`
+	Language            string              `yaml:"language,omitempty" description:"Primary language of the project (Node, Python, Go, Rust, Java, ...)"`
+	Version             string              `yaml:"version,omitempty" description:"Runtime version required by the project"`
+	RunCommand          string              `yaml:"run,omitempty" description:"Command that starts the application"`
+	SetupCommand        string              `yaml:"setup,omitempty" description:"Command that installs dependencies before running"`
+	SetupRequired       bool                `yaml:"setup_required,omitempty" description:"Whether the setup command must run before the first start"`
+	SetupTimeoutMinutes int                 `yaml:"setup_timeout_minutes,omitempty" description:"Minutes the setup command may run (0 = the 30 minute default)"`
+	RunTimeoutMinutes   int                 `yaml:"run_timeout_minutes,omitempty" description:"Minutes the run command may run before it is stopped (0 = no timeout)"`
+	PackageManager      string              `yaml:"package_manager,omitempty" description:"Package manager used by the project (npm, pnpm, yarn, pip, cargo, ...)"`
+	IsMonorepo          bool                `yaml:"is_monorepo,omitempty" description:"Whether the project is a monorepo"`
+	MonorepoRoot        string              `yaml:"monorepo_root,omitempty" description:"Path to the monorepo root, if different from the project directory"`
+	WorkspaceRunner     string              `yaml:"workspace_runner,omitempty" description:"Primary monorepo task runner when several are configured" enum:"nx,turbo,lerna"`
+	HealthCheck         string              `yaml:"health_check,omitempty" description:"URL that responds once the app is ready"`
+	BaseImage           string              `yaml:"base_image,omitempty" description:"Docker base image used in container mode (overrides the auto-detected image)"`
+	HasDockerCompose    bool                `yaml:"has_docker_compose,omitempty" description:"Whether the project has a docker-compose.yml for its backing services"`
+	DockerCompose       DockerComposeConfig `yaml:"docker_compose,omitempty" description:"Starting the Compose services together with the app"`
+	Warning             string              `yaml:"warning,omitempty" description:"Setup warning recorded by octo init (e.g. Dockerfile and local runtime versions differ)"`
+	EnvVars             []EnvVar            `yaml:"env_vars,omitempty" description:"Environment variables the project expects"`
+	EnvVarGroups        []EnvVarGroup       `yaml:"env_var_groups,omitempty" description:"Environment variables organized by category (AWS, Database, ...)"`
+	EnvVarSchema        string              `yaml:"env_var_schema,omitempty" description:"Path to a JSON Schema file the env var values are validated against"`
+	Services            []Service           `yaml:"services,omitempty" description:"Individually runnable services, selectable with --services"`
+	Aliases             map[string]string   `yaml:"aliases,omitempty" description:"Short names for the services above (alias: service name), usable with --services; Procfile processes cannot be aliased"`
+	WatchPaths          []string            `yaml:"watch_paths,omitempty" description:"Paths --watch observes (empty = project root)"`
+	WatchIgnorePaths    []string            `yaml:"watch_ignore_paths,omitempty" description:"Names or relative paths --watch skips"`
+	Thermal             ThermalConfig       `yaml:"thermal,omitempty" description:"Thermal and resource management settings"`
+}
+
+// Service is an individually runnable service inside a monorepo
+type Service struct {
+	Name       string `yaml:"name" description:"Service name used with --services"`
+	Path       string `yaml:"path,omitempty" description:"Service directory, relative to the project (or monorepo) root"`
+	RunCommand string `yaml:"run" description:"Command that starts the service"`
 }
 
 // EnvVar represents a required environment variable
 type EnvVar struct {
-	Name     string `yaml:"name"`
-	Required bool   `yaml:"required"`
+	Name     string `yaml:"name" description:"Environment variable name"`
+	Required bool   `yaml:"required" description:"Whether the variable must be set before running"`
+}
+
+// EnvVarGroup is a named category of related environment variables
+type EnvVarGroup struct {
+	Name        string   `yaml:"name" description:"Category name"`
+	Description string   `yaml:"description,omitempty" description:"What the variables in this group configure"`
+	Vars        []EnvVar `yaml:"vars" description:"Environment variables in this category"`
+}
+
+// envVarCategories assigns environment variables to groups by name prefix, checked in order
+var envVarCategories = []struct {
+	name        string
+	description string
+	prefixes    []string
+}{
+	{"AWS", "Amazon Web Services credentials and settings", []string{"AWS_", "S3_"}},
+	{"Database", "Database connection settings", []string{"DATABASE_", "DB_", "POSTGRES_", "MYSQL_", "MONGO"}},
+	{"Cache", "Cache and key-value store settings", []string{"REDIS_", "MEMCACHE"}},
+	{"Auth", "Authentication and session secrets", []string{"AUTH_", "JWT_", "SESSION_", "NEXTAUTH_", "OAUTH_"}},
+	{"Email", "Outgoing mail settings", []string{"SMTP_", "MAIL_", "SENDGRID_", "MAILGUN_"}},
+	{"Payments", "Payment provider keys", []string{"STRIPE_", "PAYPAL_"}},
+}
+
+// generalEnvVarGroup holds variables that match no category
+const generalEnvVarGroup = "General"
+
+// GroupEnvVars sorts environment variables into categories by name prefix.
+// Variables that match no category are collected in a "General" group.
+// It returns nil if no variable matches a category, since a flat list is clearer then.
+func GroupEnvVars(vars []EnvVar) []EnvVarGroup {
+	byName := make(map[string]*EnvVarGroup)
+	var order []string
+	categorized := false
+
+	for _, v := range vars {
+		name, description := generalEnvVarGroup, "Other settings"
+		for _, category := range envVarCategories {
+			if hasAnyPrefix(v.Name, category.prefixes) {
+				name, description = category.name, category.description
+				categorized = true
+				break
+			}
+		}
+
+		group, ok := byName[name]
+		if !ok {
+			group = &EnvVarGroup{Name: name, Description: description}
+			byName[name] = group
+			order = append(order, name)
+		}
+		group.Vars = append(group.Vars, v)
+	}
+
+	if !categorized {
+		return nil
+	}
+
+	// Keep the categories in table order with General last
+	groups := make([]EnvVarGroup, 0, len(order))
+	for _, category := range envVarCategories {
+		if group, ok := byName[category.name]; ok {
+			groups = append(groups, *group)
+		}
+	}
+	if group, ok := byName[generalEnvVarGroup]; ok {
+		groups = append(groups, *group)
+	}
+	return groups
+}
+
+// hasAnyPrefix reports whether s starts with one of prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllEnvVars returns the flat env_vars list followed by grouped variables not already in it
+func (bp Blueprint) AllEnvVars() []EnvVar {
+	all := append([]EnvVar(nil), bp.EnvVars...)
+	seen := make(map[string]bool, len(all))
+	for _, v := range all {
+		seen[v.Name] = true
+	}
+	for _, group := range bp.EnvVarGroups {
+		for _, v := range group.Vars {
+			if !seen[v.Name] {
+				seen[v.Name] = true
+				all = append(all, v)
+			}
+		}
+	}
+	return all
+}
+
+// SelectServices returns the services matching the given names, in the order requested.
+// It returns an error listing the available services if any name is unknown.
+func (bp Blueprint) SelectServices(names []string) ([]Service, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var available []string
+	byName := make(map[string]Service, len(bp.Services))
+	for _, svc := range bp.Services {
+		byName[svc.Name] = svc
+		available = append(available, svc.Name)
+	}
+
+	if len(available) == 0 {
+		return nil, errors.New("no services defined in configuration; add a 'services' section to .octo.yaml")
+	}
+
+	var selected []Service
+	var unknown []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if target, ok := bp.Aliases[name]; ok {
+			name = target
+		}
+		svc, ok := byName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected = append(selected, svc)
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown service(s): %s (available: %s)",
+			strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+
+	return selected, nil
+}
+
+// ResolveServices returns the services to run for the names given with --services.
+// Without a run command, the processes of a Procfile in dir are used instead of the
+// configured services, and fromProcfile is set; names then select among those processes.
+// Aliases are validated against the configured services when the file is read, so they
+// cannot refer to Procfile processes.
+func (bp Blueprint) ResolveServices(dir string, names []string) (services []Service, fromProcfile bool, err error) {
+	if bp.RunCommand == "" && (len(bp.Services) == 0 || len(names) == 0) {
+		procfileServices, err := ReadProcfile(filepath.Join(dir, "Procfile"))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read Procfile: %w", err)
+		}
+		if len(procfileServices) > 0 {
+			if len(names) == 0 {
+				return procfileServices, true, nil
+			}
+			bp.Services = procfileServices
+			services, err := bp.SelectServices(names)
+			return services, err == nil, err
+		}
+	}
+
+	services, err = bp.SelectServices(names)
+	return services, false, err
+}
+
+// procfileLinePattern matches Procfile entries like "web: bundle exec rails server"
+var procfileLinePattern = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.+)$`)
+
+// ReadProcfile parses a Heroku-style Procfile into services, one per process type.
+// It returns (nil, nil) if the file does not exist.
+func ReadProcfile(path string) ([]Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var services []Service
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		matches := procfileLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		services = append(services, Service{Name: matches[1], RunCommand: strings.TrimSpace(matches[2])})
+	}
+
+	return services, nil
+}
+
+// DetectBaseImage returns the base image of the final stage in the project's Dockerfile.
+// It returns an empty string if there is no Dockerfile or no usable FROM line.
+func DetectBaseImage(projectPath string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, "Dockerfile"))
+	if err != nil {
+		return ""
+	}
+
+	image := ""
+	stageImages := make(map[string]string) // stage name -> resolved base image
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+
+		// FROM [--platform=<platform>] <image> [AS <name>]
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		// A stage built FROM an earlier stage inherits that stage's image
+		image = args[0]
+		if stageImage, ok := stageImages[strings.ToLower(image)]; ok {
+			image = stageImage
+		}
+		if len(args) >= 3 && strings.EqualFold(args[1], "AS") {
+			stageImages[strings.ToLower(args[2])] = image
+		}
+	}
+
+	return image
+}
+
+// DetectExposedPorts returns the ports declared by EXPOSE instructions in the project's Dockerfile,
+// in the order they appear. Protocol suffixes ("8080/tcp") are dropped; variables and ranges are skipped.
+func DetectExposedPorts(projectPath string) []int {
+	data, err := os.ReadFile(filepath.Join(projectPath, "Dockerfile"))
+	if err != nil {
+		return nil
+	}
+
+	var exposed []int
+	seen := make(map[int]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "EXPOSE") {
+			continue
+		}
+
+		// EXPOSE <port>[/<protocol>] [<port>[/<protocol>]...]
+		for _, field := range fields[1:] {
+			portStr, _, _ := strings.Cut(field, "/")
+			port, err := strconv.Atoi(portStr)
+			if err != nil || port <= 0 || port > 65535 || seen[port] {
+				continue
+			}
+			seen[port] = true
+			exposed = append(exposed, port)
+		}
+	}
+
+	return exposed
+}
+
+// dockerRuntimeImages maps a language to the official Docker images that pin its runtime version
+var dockerRuntimeImages = map[string][]string{
+	"Node":   {"node"},
+	"Python": {"python"},
+	"Go":     {"golang"},
+	"Ruby":   {"ruby"},
+	"Rust":   {"rust"},
+	"Java":   {"openjdk", "eclipse-temurin", "amazoncorretto"},
+	"Kotlin": {"openjdk", "eclipse-temurin", "amazoncorretto"},
+}
+
+// versionPattern finds the first dotted version number in a tag or `--version` output
+var versionPattern = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// BaseImageVersionWarning compares the runtime version pinned by a Docker base image
+// (e.g. "node:18-alpine") with the locally installed version (raw `--version` output).
+// Kotlin images are JDK images, so for Kotlin localVersion must be the `java -version` output.
+// It returns a warning if they differ, or "" if they match or either version is unknown.
+func BaseImageVersionWarning(baseImage string, language string, localVersion string) string {
+	// Strip the registry/namespace first so "localhost:5000/node:18" works too
+	name := baseImage[strings.LastIndex(baseImage, "/")+1:]
+	repo, tag, found := strings.Cut(name, ":")
+	if !found {
+		return ""
+	}
+
+	matchesLanguage := false
+	for _, image := range dockerRuntimeImages[language] {
+		if repo == image {
+			matchesLanguage = true
+			break
+		}
+	}
+	if !matchesLanguage {
+		return ""
+	}
+
+	// Only tags that start with a version pin anything ("latest", "alpine", "lts" do not)
+	pinned := versionPattern.FindString(tag)
+	if pinned == "" || !strings.HasPrefix(tag, pinned) {
+		return ""
+	}
+	local := versionPattern.FindString(localVersion)
+	if local == "" {
+		return ""
+	}
+	runtimeName := language
+	if language == "Kotlin" {
+		runtimeName = "Java"
+	}
+	// Java 8 and earlier report themselves as 1.x
+	if runtimeName == "Java" && strings.HasPrefix(local, "1.") {
+		local = strings.TrimPrefix(local, "1.")
+	}
+
+	// "18" matches 18.19.0 and "3.11" matches 3.11.4
+	if local == pinned || strings.HasPrefix(local, pinned+".") {
+		return ""
+	}
+	return fmt.Sprintf("Dockerfile uses %s (%s %s) but %s %s is installed locally", baseImage, runtimeName, pinned, runtimeName, local)
 }
 
 // FromAnalysis converts an analysis result into a basic blueprint.
@@ -51,6 +458,11 @@ func FromAnalysis(a analyzer.Analysis) Blueprint {
 
 // FromProjectInfo converts a ProjectInfo result into a full blueprint.
 func FromProjectInfo(p analyzer.ProjectInfo) Blueprint {
+	var services []Service
+	for _, svc := range p.Services {
+		services = append(services, Service{Name: svc.Name, RunCommand: svc.RunCommand})
+	}
+
 	return Blueprint{
 		Name:           p.Name,
 		Language:       p.Language,
@@ -61,26 +473,108 @@ func FromProjectInfo(p analyzer.ProjectInfo) Blueprint {
 		PackageManager: p.PackageManager,
 		IsMonorepo:     p.IsMonorepo,
 		MonorepoRoot:   p.MonorepoRoot,
+		Services:       services,
+
+		WatchIgnorePaths: DefaultWatchIgnorePaths(p.Language),
+	}
+}
+
+// DefaultWatchIgnorePaths returns build output and dependency directories
+// that should not trigger a restart for the given language
+func DefaultWatchIgnorePaths(language string) []string {
+	ignore := []string{".git"}
+
+	switch language {
+	case "Node":
+		ignore = append(ignore, "node_modules", "dist", "build", ".next")
+	case "Python":
+		ignore = append(ignore, "__pycache__", "build", "dist")
+	case "Java", "Kotlin":
+		ignore = append(ignore, "target", "build", ".gradle")
+	case "Rust":
+		ignore = append(ignore, "target")
+	case "Swift":
+		ignore = append(ignore, ".build")
+	case "Go":
+		ignore = append(ignore, "dist")
+	default:
+		ignore = append(ignore, "node_modules", "dist", "build", "target", ".next", "__pycache__")
 	}
+
+	return ignore
 }
 
 // Write writes the blueprint as a YAML file.
 func Write(path string, bp Blueprint) error {
+	data, err := Marshal(bp)
+	if err != nil {
+		return err
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
+	_, err = f.Write(data)
+	return err
+}
+
+// Marshal returns the YAML Write would save for bp
+func Marshal(bp Blueprint) ([]byte, error) {
 	// Marshal the blueprint to YAML
 	data, err := yaml.Marshal(&bp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Write the YAML content
-	_, err = f.Write(data)
-	return err
+	// Explain each field for hand-editing
+	return annotateYAML(data), nil
+}
+
+// fieldComments explains each top-level key in generated .octo.yaml files
+var fieldComments = map[string]string{
+	"name":                  "Project name shown in the dashboard and `octo ps`",
+	"language":              "Detected language; decides the runtime check and port flag style",
+	"version":               "Runtime version the project expects",
+	"run":                   "Command that starts the app (override once with `octo run --command`)",
+	"setup":                 "Command run before the app starts, e.g. to install dependencies or build",
+	"setup_required":        "If true, the app is not started when setup fails",
+	"setup_timeout_minutes": "Minutes setup may take before it is aborted (0 = 30 minutes)",
+	"run_timeout_minutes":   "Minutes the app may run before it is stopped (0 = no limit)",
+	"package_manager":       "Package manager used to install dependencies",
+	"is_monorepo":           "Whether this is a monorepo with several workspace packages",
+	"monorepo_root":         "Directory commands run in, if not the project directory",
+	"workspace_runner":      "Task runner used for the monorepo (nx, turbo or lerna)",
+	"health_check":          "URL polled until the app responds",
+	"base_image":            "Docker image used in container mode",
+	"has_docker_compose":    "Whether docker-compose.yml defines the app's databases and caches",
+	"docker_compose":        "Compose services `octo run` starts first (start_on_run) and stops on exit",
+	"warning":               "Setup issue found by `octo init`, shown on every run",
+	"env_vars":              "Environment variables the app reads; required ones are checked before running",
+	"env_var_groups":        "The same variables organized by category",
+	"env_var_schema":        "JSON Schema file env var values are checked against before running",
+	"services":              "Individually runnable services, started with `octo run --services <name>`",
+	"aliases":               "Short names for services, e.g. `client: apps/client` for `octo run --services client`",
+	"watch_paths":           "Paths `octo run --watch` restarts on (empty = the whole project)",
+	"watch_ignore_paths":    "Files and directories `octo run --watch` ignores",
+	"thermal":               "Concurrency and cool-down settings for large monorepos",
+}
+
+// annotateYAML inserts a comment line from fieldComments above each top-level key
+func annotateYAML(data []byte) []byte {
+	var out bytes.Buffer
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		// Top-level keys are the only unindented lines yaml.Marshal writes
+		if key, _, found := strings.Cut(line, ":"); found && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			if comment, ok := fieldComments[key]; ok {
+				out.WriteString("# " + comment + "\n")
+			}
+		}
+		out.WriteString(line)
+	}
+	return out.Bytes()
 }
 
 // Read reads a YAML-like file and extracts the blueprint fields.
@@ -95,9 +589,48 @@ func Read(path string) (Blueprint, error) {
 		return Blueprint{}, err
 	}
 
-	if bp.Name == "" {
-		return Blueprint{}, errors.New("invalid configuration: missing name")
+	if err := bp.Validate(); err != nil {
+		return Blueprint{}, err
 	}
 
 	return bp, nil
+}
+
+// Validate checks the blueprint for values octo cannot run with
+func (bp Blueprint) Validate() error {
+	if bp.Name == "" {
+		return errors.New("invalid configuration: missing name")
+	}
+	if bp.SetupTimeoutMinutes < 0 {
+		return fmt.Errorf("invalid configuration: setup_timeout_minutes must not be negative (got %d)", bp.SetupTimeoutMinutes)
+	}
+	if bp.RunTimeoutMinutes < 0 {
+		return fmt.Errorf("invalid configuration: run_timeout_minutes must not be negative (got %d)", bp.RunTimeoutMinutes)
+	}
+	return bp.validateAliases()
+}
+
+// validateAliases checks that every alias points at a service and none shadows a service name
+func (bp Blueprint) validateAliases() error {
+	services := make(map[string]bool, len(bp.Services))
+	for _, svc := range bp.Services {
+		services[svc.Name] = true
+	}
+
+	aliases := make([]string, 0, len(bp.Aliases))
+	for alias := range bp.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		target := bp.Aliases[alias]
+		if services[alias] {
+			return fmt.Errorf("invalid configuration: alias %q conflicts with the service of the same name", alias)
+		}
+		if !services[target] {
+			return fmt.Errorf("invalid configuration: alias %q refers to unknown service %q", alias, target)
+		}
+	}
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,311 @@
+package blueprint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test %s: %v", name, err)
+	}
+	return path
+}
+
+func TestReadProcfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Service
+	}{
+		{
+			name:    "web and worker",
+			content: "web: bundle exec rails server -p $PORT\nworker: bundle exec sidekiq\n",
+			want: []Service{
+				{Name: "web", RunCommand: "bundle exec rails server -p $PORT"},
+				{Name: "worker", RunCommand: "bundle exec sidekiq"},
+			},
+		},
+		{
+			name:    "comments and blank lines",
+			content: "# processes\n\nweb: npm start\n   \n  # worker: disabled\nworker:   node worker.js  \n",
+			want: []Service{
+				{Name: "web", RunCommand: "npm start"},
+				{Name: "worker", RunCommand: "node worker.js"},
+			},
+		},
+		{
+			name:    "malformed lines are skipped",
+			content: "not a process\nweb:\nrelease_phase: ./migrate.sh\n",
+			want: []Service{
+				{Name: "release_phase", RunCommand: "./migrate.sh"},
+			},
+		},
+		{
+			name:    "no processes",
+			content: "# empty\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		path := writeTestFile(t, "Procfile", tt.content)
+		got, err := ReadProcfile(path)
+		if err != nil {
+			t.Fatalf("%s: ReadProcfile returned error: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ReadProcfile = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadProcfileMissing(t *testing.T) {
+	services, err := ReadProcfile(filepath.Join(t.TempDir(), "Procfile"))
+	if err != nil || services != nil {
+		t.Errorf("ReadProcfile = %v, %v; want nil, nil for a missing file", services, err)
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	services := []Service{{Name: "apps/client"}, {Name: "apps/api"}}
+
+	tests := []struct {
+		name    string
+		aliases map[string]string
+		wantErr string
+	}{
+		{"no aliases", nil, ""},
+		{"valid aliases", map[string]string{"client": "apps/client", "api": "apps/api"}, ""},
+		{"alias shadows a service", map[string]string{"apps/api": "apps/client"}, `alias "apps/api" conflicts with the service of the same name`},
+		{"unknown target", map[string]string{"web": "apps/web"}, `alias "web" refers to unknown service "apps/web"`},
+	}
+
+	for _, tt := range tests {
+		bp := Blueprint{Name: "demo", Services: services, Aliases: tt.aliases}
+		err := bp.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: Validate returned error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: Validate error = %v, want it to contain %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSelectServices(t *testing.T) {
+	bp := Blueprint{
+		Name:     "demo",
+		Services: []Service{{Name: "apps/client"}, {Name: "apps/api"}},
+		Aliases:  map[string]string{"client": "apps/client"},
+	}
+
+	tests := []struct {
+		names   []string
+		want    []string
+		wantErr string
+	}{
+		{nil, nil, ""},
+		{[]string{"apps/api"}, []string{"apps/api"}, ""},
+		{[]string{"client", " apps/api ", ""}, []string{"apps/client", "apps/api"}, ""},
+		{[]string{"client", "web"}, nil, "unknown service(s): web"},
+	}
+
+	for _, tt := range tests {
+		selected, err := bp.SelectServices(tt.names)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SelectServices(%q) error = %v, want it to contain %q", tt.names, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SelectServices(%q) returned error: %v", tt.names, err)
+			continue
+		}
+		var got []string
+		for _, svc := range selected {
+			got = append(got, svc.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SelectServices(%q) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+
+	if _, err := (Blueprint{Name: "demo"}).SelectServices([]string{"web"}); err == nil {
+		t.Error("expected an error when no services are defined")
+	}
+}
+
+func TestResolveServicesProcfile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Procfile"), []byte("web: npm start\nworker: node worker.js\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		bp           Blueprint
+		names        []string
+		want         []string
+		fromProcfile bool
+		wantErr      string
+	}{
+		{"all processes", Blueprint{Name: "demo"}, nil, []string{"web", "worker"}, true, ""},
+		// --services is resolved after the fallback, so processes can be selected
+		{"select a process", Blueprint{Name: "demo"}, []string{"worker"}, []string{"worker"}, true, ""},
+		{"unknown process", Blueprint{Name: "demo"}, []string{"api"}, nil, false, "unknown service(s): api (available: web, worker)"},
+		{"run command wins", Blueprint{Name: "demo", RunCommand: "npm run dev"}, nil, nil, false, ""},
+		{
+			"configured services are selected",
+			Blueprint{Name: "demo", Services: []Service{{Name: "apps/api"}}, Aliases: map[string]string{"api": "apps/api"}},
+			[]string{"api"}, []string{"apps/api"}, false, "",
+		},
+	}
+
+	for _, tt := range tests {
+		services, fromProcfile, err := tt.bp.ResolveServices(dir, tt.names)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: ResolveServices error = %v, want it to contain %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ResolveServices returned error: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, svc := range services {
+			got = append(got, svc.Name)
+		}
+		if !reflect.DeepEqual(got, tt.want) || fromProcfile != tt.fromProcfile {
+			t.Errorf("%s: ResolveServices = %q, %v; want %q, %v", tt.name, got, fromProcfile, tt.want, tt.fromProcfile)
+		}
+	}
+}
+
+func TestThermalModes(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    ConcurrencyStrategy
+		wantErr bool
+	}{
+		{"", StrategyAuto, false},
+		{"performance", StrategyPerformance, false},
+		{"  Cool ", StrategyCool, false},
+		{"turbo", "", true},
+	}
+
+	for _, tt := range tests {
+		path := writeTestFile(t, ".octo.yaml", "name: demo\nthermal:\n  mode: \""+tt.mode+"\"\n")
+		bp, err := Read(path)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), `invalid thermal mode "turbo"`) {
+				t.Errorf("Read with mode %q: error = %v, want an invalid thermal mode error", tt.mode, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Read with mode %q returned error: %v", tt.mode, err)
+			continue
+		}
+		if bp.Thermal.Mode != tt.want {
+			t.Errorf("Read with mode %q: Thermal.Mode = %q, want %q", tt.mode, bp.Thermal.Mode, tt.want)
+		}
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	want := Blueprint{
+		Name:                "demo",
+		Language:            "Node",
+		RunCommand:          "npm start",
+		SetupCommand:        "npm install",
+		SetupTimeoutMinutes: 10,
+		EnvVars:             []EnvVar{{Name: "DATABASE_URL", Required: true}},
+		Services: []Service{
+			{Name: "apps/client", Path: "apps/client", RunCommand: "npm run dev"},
+			{Name: "apps/api", Path: "apps/api", RunCommand: "npm run start"},
+		},
+		Aliases:          map[string]string{"client": "apps/client"},
+		WatchIgnorePaths: []string{"node_modules", "dist"},
+		Thermal:          ThermalConfig{Mode: StrategyBalanced, Concurrency: 4},
+	}
+
+	data, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	// annotateYAML adds a comment above top-level keys only
+	out := string(data)
+	for _, comment := range []string{"# " + fieldComments["name"] + "\nname:", "# " + fieldComments["services"] + "\nservices:"} {
+		if !strings.Contains(out, comment) {
+			t.Errorf("expected the marshalled YAML to contain %q", comment)
+		}
+	}
+	if n := strings.Count(out, "# "+fieldComments["name"]); n != 1 {
+		t.Errorf("expected only the top-level name key to be commented, got %d comments", n)
+	}
+
+	path := writeTestFile(t, ".octo.yaml", out)
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read(Marshal(bp)) = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadRejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing name":     "language: Go\n",
+		"negative timeout": "name: demo\nrun_timeout_minutes: -1\n",
+		"alias collision":  "name: demo\nservices:\n  - name: web\n    run: npm start\naliases:\n  web: web\n",
+	}
+	for name, content := range tests {
+		if _, err := Read(writeTestFile(t, ".octo.yaml", content)); err == nil {
+			t.Errorf("%s: expected Read to return an error", name)
+		}
+	}
+}
+
+func TestBaseImageVersionWarning(t *testing.T) {
+	tests := []struct {
+		image    string
+		language string
+		local    string
+		warn     bool
+	}{
+		{"node:18-alpine", "Node", "v18.19.0", false},
+		{"node:20", "Node", "v18.19.0", true},
+		{"localhost:5000/python:3.11-slim", "Python", "Python 3.11.4", false},
+		{"node:lts", "Node", "v18.19.0", false},
+		{"eclipse-temurin:8-jdk", "Java", `openjdk version "1.8.0_392"`, false},
+		// Kotlin images pin the JDK, so the local java -version is compared
+		{"eclipse-temurin:17-jdk", "Kotlin", `openjdk version "17.0.9" 2023-10-17`, false},
+		{"eclipse-temurin:21-jdk", "Kotlin", `openjdk version "17.0.9" 2023-10-17`, true},
+		{"ruby:3.2", "Python", "Python 3.11.4", false},
+	}
+
+	for _, tt := range tests {
+		got := BaseImageVersionWarning(tt.image, tt.language, tt.local)
+		if (got != "") != tt.warn {
+			t.Errorf("BaseImageVersionWarning(%q, %q, %q) = %q, want warning: %v", tt.image, tt.language, tt.local, got, tt.warn)
+		}
+	}
+
+	if got := BaseImageVersionWarning("eclipse-temurin:21-jdk", "Kotlin", `openjdk version "17.0.9"`); !strings.Contains(got, "Java 21") {
+		t.Errorf("expected the Kotlin warning to name the Java version, got %q", got)
+	}
+}
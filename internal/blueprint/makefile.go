@@ -0,0 +1,60 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Makefile renders a Makefile whose targets wrap the octo commands for the blueprint,
+// so developers who reach for make first can discover them. configPath is the
+// configuration file the targets pass to octo (empty = .octo.yaml).
+// There is no logs target: octo run streams the app's logs in the foreground
+// and keeps no log file to read them back from.
+func Makefile(bp Blueprint, configPath string) string {
+	if configPath == "" {
+		configPath = ".octo.yaml"
+	}
+
+	// Services get their own run-<name> target when the name is usable as a make target
+	var services []string
+	for _, svc := range bp.Services {
+		if svc.Name != "" && !strings.ContainsAny(svc.Name, " \t:#=$%") {
+			services = append(services, svc.Name)
+		}
+	}
+
+	phony := []string{"run", "init", "stop", "ps"}
+	for _, name := range services {
+		phony = append(phony, "run-"+name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by octo init. The targets mirror %s; edit freely.\n", configPath)
+	b.WriteString("OCTO ?= octo\n")
+	fmt.Fprintf(&b, "OCTO_CONFIG ?= %s\n\n", configPath)
+	fmt.Fprintf(&b, ".PHONY: %s\n\n", strings.Join(phony, " "))
+
+	b.WriteString("# Start the app\n")
+	b.WriteString("run:\n\t$(OCTO) run -c $(OCTO_CONFIG)\n\n")
+	for _, name := range services {
+		fmt.Fprintf(&b, "# Start only the %s service\n", name)
+		fmt.Fprintf(&b, "run-%s:\n\t$(OCTO) run -c $(OCTO_CONFIG) --services %s\n\n", name, name)
+	}
+	b.WriteString("# Refresh the detected settings in the configuration\n")
+	b.WriteString("init:\n\t$(OCTO) init --auto --output $(OCTO_CONFIG)\n\n")
+	b.WriteString("# Stop the app started by `make run`\n")
+	b.WriteString("stop:\n\t$(OCTO) stop\n\n")
+	b.WriteString("# List running octo sessions and their ports\n")
+	b.WriteString("ps:\n\t$(OCTO) ps\n")
+
+	return b.String()
+}
+
+// WriteMakefile writes the Makefile for the blueprint to path
+func WriteMakefile(path string, bp Blueprint, configPath string) error {
+	if err := os.WriteFile(path, []byte(Makefile(bp, configPath)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package blueprint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMakefile(t *testing.T) {
+	bp := Blueprint{
+		Name: "demo",
+		Services: []Service{
+			{Name: "apps/api"},
+			{Name: "db:migrate"}, // ':' would start a new rule, so no target
+			{Name: "worker"},
+		},
+	}
+
+	want := "# Generated by octo init. The targets mirror config/octo.yaml; edit freely.\n" +
+		"OCTO ?= octo\n" +
+		"OCTO_CONFIG ?= config/octo.yaml\n" +
+		"\n" +
+		".PHONY: run init stop ps run-apps/api run-worker\n" +
+		"\n" +
+		"# Start the app\n" +
+		"run:\n\t$(OCTO) run -c $(OCTO_CONFIG)\n" +
+		"\n" +
+		"# Start only the apps/api service\n" +
+		"run-apps/api:\n\t$(OCTO) run -c $(OCTO_CONFIG) --services apps/api\n" +
+		"\n" +
+		"# Start only the worker service\n" +
+		"run-worker:\n\t$(OCTO) run -c $(OCTO_CONFIG) --services worker\n" +
+		"\n" +
+		"# Refresh the detected settings in the configuration\n" +
+		"init:\n\t$(OCTO) init --auto --output $(OCTO_CONFIG)\n" +
+		"\n" +
+		"# Stop the app started by `make run`\n" +
+		"stop:\n\t$(OCTO) stop\n" +
+		"\n" +
+		"# List running octo sessions and their ports\n" +
+		"ps:\n\t$(OCTO) ps\n"
+
+	if got := Makefile(bp, "config/octo.yaml"); got != want {
+		t.Errorf("Makefile() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMakefileDefaultConfig(t *testing.T) {
+	got := Makefile(Blueprint{Name: "demo"}, "")
+	want := "OCTO_CONFIG ?= .octo.yaml\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("Makefile() = %q, want it to default to .octo.yaml", got)
+	}
+}
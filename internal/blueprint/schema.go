@@ -0,0 +1,98 @@
+package blueprint
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// schemaURL is the JSON Schema draft the generated schema conforms to
+const schemaURL = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns a JSON Schema describing .octo.yaml.
+// It is generated by reflecting over Blueprint, using the yaml tags for
+// property names and the description/enum tags for documentation.
+func JSONSchema() ([]byte, error) {
+	schema := schemaForType(reflect.TypeOf(Blueprint{}))
+	schema["$schema"] = schemaURL
+	schema["title"] = "Octo configuration (.octo.yaml)"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaForType builds the schema fragment for a single Go type
+func schemaForType(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		return schemaForStruct(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// schemaForStruct builds an object schema from a struct's exported, yaml-tagged fields.
+// Fields without omitempty are listed as required, except booleans whose zero value is meaningful.
+func schemaForStruct(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		omitempty := false
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omitempty = true
+			}
+		}
+
+		prop := schemaForType(field.Type)
+		if desc := field.Tag.Get("description"); desc != "" {
+			prop["description"] = desc
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop["enum"] = strings.Split(enum, ",")
+		}
+		properties[name] = prop
+
+		if !omitempty && field.Type.Kind() != reflect.Bool {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
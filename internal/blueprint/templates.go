@@ -0,0 +1,48 @@
+package blueprint
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateFS holds the predefined project templates used by `octo init --template`
+//
+//go:embed templates/*.yaml
+var templateFS embed.FS
+
+// TemplateNames returns the names of all available project templates
+func TemplateNames() []string {
+	entries, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromTemplate builds a blueprint from a predefined template.
+// The project name is not part of the template and must be supplied by the caller.
+func FromTemplate(name string, projectName string) (Blueprint, error) {
+	data, err := templateFS.ReadFile(path.Join("templates", name+".yaml"))
+	if err != nil {
+		return Blueprint{}, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(TemplateNames(), ", "))
+	}
+
+	var bp Blueprint
+	if err := yaml.Unmarshal(data, &bp); err != nil {
+		return Blueprint{}, fmt.Errorf("invalid template %q: %w", name, err)
+	}
+
+	bp.Name = projectName
+	return bp, nil
+}
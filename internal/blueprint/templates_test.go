@@ -0,0 +1,48 @@
+package blueprint
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTemplateNames(t *testing.T) {
+	names := TemplateNames()
+	want := []string{"fastapi", "go-gin", "nextjs", "node-express", "rails", "spring-boot"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("TemplateNames() = %q, want %q", names, want)
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("TemplateNames() = %q, want sorted names", names)
+	}
+}
+
+func TestFromTemplate(t *testing.T) {
+	for _, name := range TemplateNames() {
+		bp, err := FromTemplate(name, "demo")
+		if err != nil {
+			t.Errorf("FromTemplate(%q) returned error: %v", name, err)
+			continue
+		}
+		if bp.Name != "demo" {
+			t.Errorf("FromTemplate(%q).Name = %q, want the project name", name, bp.Name)
+		}
+		if bp.RunCommand == "" {
+			t.Errorf("FromTemplate(%q) has no run command", name)
+		}
+		if err := bp.Validate(); err != nil {
+			t.Errorf("FromTemplate(%q).Validate() returned error: %v", name, err)
+		}
+	}
+}
+
+func TestFromTemplateUnknown(t *testing.T) {
+	_, err := FromTemplate("django", "demo")
+	if err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+	if !strings.Contains(err.Error(), `unknown template "django"`) || !strings.Contains(err.Error(), "node-express") {
+		t.Errorf("FromTemplate error = %q, want it to name the template and list the available ones", err)
+	}
+}
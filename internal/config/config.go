@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalConfig holds user preferences stored in ~/.octo/config.yaml that apply to every project.
+// Command-line flags win over these defaults, which win over each project's .octo.yaml.
+type GlobalConfig struct {
+	Theme       string        `yaml:"theme,omitempty"`         // Dashboard color theme, saved by `octo run --theme`
+	Environment string        `yaml:"env,omitempty"`           // Default for `octo run --env`
+	Shell       string        `yaml:"shell,omitempty"`         // Default for `octo run --shell`
+	Concurrency int           `yaml:"concurrency,omitempty"`   // Default for `octo run --concurrency`
+	Thermal     ThermalConfig `yaml:"thermal,omitempty"`       // Thermal settings applied to every project
+	NoTUI       bool          `yaml:"no_tui,omitempty"`        // Default for `octo run --no-tui`
+	NoBrowser   bool          `yaml:"no_browser,omitempty"`    // Default for `octo run --no-browser`
+	NoPortShift bool          `yaml:"no_port_shift,omitempty"` // Default for `octo run --no-port-shift`
+	Notify      bool          `yaml:"notify,omitempty"`        // Default for `octo run --notify`
+	SkipDoppler bool          `yaml:"skip_doppler,omitempty"`  // Default for `octo run --skip-doppler`
+	AutoInstall bool          `yaml:"auto_install,omitempty"`  // Default for `octo init --auto-install`
+}
+
+// ThermalConfig holds the global thermal settings
+type ThermalConfig struct {
+	Mode string `yaml:"mode,omitempty"` // Default for `octo run --thermal-mode` (auto, performance, balanced, cool, manual)
+}
+
+// RunFlags returns the `octo run` flags this configuration sets, keyed by flag name.
+// The theme is left out because --theme also saves it.
+func (c GlobalConfig) RunFlags() map[string]string {
+	flags := make(map[string]string)
+	if c.Environment != "" {
+		flags["env"] = c.Environment
+	}
+	if c.Shell != "" {
+		flags["shell"] = c.Shell
+	}
+	if c.Concurrency != 0 {
+		flags["concurrency"] = strconv.Itoa(c.Concurrency)
+	}
+	if c.Thermal.Mode != "" {
+		flags["thermal-mode"] = c.Thermal.Mode
+	}
+	if c.NoTUI {
+		flags["no-tui"] = "true"
+	}
+	if c.NoBrowser {
+		flags["no-browser"] = "true"
+	}
+	if c.NoPortShift {
+		flags["no-port-shift"] = "true"
+	}
+	if c.Notify {
+		flags["notify"] = "true"
+	}
+	if c.SkipDoppler {
+		flags["skip-doppler"] = "true"
+	}
+	return flags
+}
+
+// InitFlags returns the `octo init` flags this configuration sets, keyed by flag name
+func (c GlobalConfig) InitFlags() map[string]string {
+	flags := make(map[string]string)
+	if c.AutoInstall {
+		flags["auto-install"] = "true"
+	}
+	return flags
+}
+
+// Path returns the location of the global configuration file (~/.octo/config.yaml)
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".octo", "config.yaml"), nil
+}
+
+// Load reads the global configuration. A missing file yields an empty configuration.
+func Load() (GlobalConfig, error) {
+	var cfg GlobalConfig
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return GlobalConfig{}, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Set stores a single top-level setting, keeping every other setting in the file
+func Set(key string, value interface{}) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]interface{})
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("invalid %s: %w", path, err)
+		}
+		if settings == nil {
+			settings = make(map[string]interface{})
+		}
+	}
+	settings[key] = value
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
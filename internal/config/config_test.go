@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// setHome points os.UserHomeDir at a temporary directory and returns the config file path
+func setHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	return filepath.Join(home, ".octo", "config.yaml")
+}
+
+func writeConfig(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	setHome(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, GlobalConfig{}) {
+		t.Errorf("Load = %+v, want an empty configuration", cfg)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := setHome(t)
+	writeConfig(t, path, "theme: dracula\nenv: staging\nconcurrency: 4\nthermal:\n  mode: cool\nno_tui: true\nauto_install: true\n")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := GlobalConfig{
+		Theme:       "dracula",
+		Environment: "staging",
+		Concurrency: 4,
+		Thermal:     ThermalConfig{Mode: "cool"},
+		NoTUI:       true,
+		AutoInstall: true,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := setHome(t)
+	writeConfig(t, path, "concurrency: lots\n")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "invalid "+path) {
+		t.Errorf("Load error = %v, want it to name %s", err, path)
+	}
+}
+
+func TestSetKeepsOtherSettings(t *testing.T) {
+	path := setHome(t)
+	writeConfig(t, path, "env: staging\nthermal:\n  mode: cool\n")
+
+	if err := Set("theme", "nord"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if err := Set("env", "production"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := GlobalConfig{Theme: "nord", Environment: "production", Thermal: ThermalConfig{Mode: "cool"}}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load after Set = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestSetCreatesFile(t *testing.T) {
+	setHome(t)
+	if err := Set("theme", "nord"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Theme != "nord" {
+		t.Errorf("Theme = %q, want %q", cfg.Theme, "nord")
+	}
+}
+
+func TestRunFlags(t *testing.T) {
+	if flags := (GlobalConfig{}).RunFlags(); len(flags) != 0 {
+		t.Errorf("RunFlags of an empty configuration = %v, want none", flags)
+	}
+
+	cfg := GlobalConfig{
+		Theme:       "nord",
+		Environment: "staging",
+		Shell:       "zsh",
+		Concurrency: 2,
+		Thermal:     ThermalConfig{Mode: "balanced"},
+		NoTUI:       true,
+		NoBrowser:   true,
+		NoPortShift: true,
+		Notify:      true,
+		SkipDoppler: true,
+		AutoInstall: true,
+	}
+	want := map[string]string{
+		"env":           "staging",
+		"shell":         "zsh",
+		"concurrency":   "2",
+		"thermal-mode":  "balanced",
+		"no-tui":        "true",
+		"no-browser":    "true",
+		"no-port-shift": "true",
+		"notify":        "true",
+		"skip-doppler":  "true",
+	}
+	// The theme is saved by --theme and auto-install belongs to `octo init`
+	if got := cfg.RunFlags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RunFlags = %v, want %v", got, want)
+	}
+}
+
+func TestInitFlags(t *testing.T) {
+	if flags := (GlobalConfig{NoTUI: true}).InitFlags(); len(flags) != 0 {
+		t.Errorf("InitFlags without auto_install = %v, want none", flags)
+	}
+	want := map[string]string{"auto-install": "true"}
+	if got := (GlobalConfig{AutoInstall: true}).InitFlags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("InitFlags = %v, want %v", got, want)
+	}
+}
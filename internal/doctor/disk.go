@@ -0,0 +1,155 @@
+package doctor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// averagePackageSize is the typical on-disk size of one installed npm package
+const averagePackageSize = 300 * 1024
+
+// diskSpaceSafetyFactor is how many times the estimated install size must be free
+const diskSpaceSafetyFactor = 2
+
+// DiskSpaceStatus compares the estimated dependency install size with the free disk space
+type DiskSpaceStatus struct {
+	LockFile      string // Lock file the estimate is based on
+	PackageCount  int    // Number of packages listed in the lock file
+	EstimatedSize uint64 // Estimated size of the installed dependencies in bytes
+	Available     uint64 // Free space on the project's filesystem in bytes
+	Sufficient    bool   // Whether at least twice the estimated size is free
+}
+
+// checkDiskSpace estimates how much space installing the project's Node dependencies needs.
+// It returns nil if there is no lock file to base an estimate on.
+func checkDiskSpace(projectPath string) *DiskSpaceStatus {
+	lockFile, count := countLockFilePackages(projectPath)
+	if count == 0 {
+		return nil
+	}
+
+	available, ok := availableDiskSpace(projectPath)
+	if !ok {
+		return nil
+	}
+
+	status := &DiskSpaceStatus{
+		LockFile:      lockFile,
+		PackageCount:  count,
+		EstimatedSize: uint64(count) * averagePackageSize,
+		Available:     available,
+	}
+	status.Sufficient = status.Available >= status.EstimatedSize*diskSpaceSafetyFactor
+	return status
+}
+
+// countLockFilePackages returns the first lock file found and the number of packages it lists
+func countLockFilePackages(projectPath string) (string, int) {
+	counters := []struct {
+		name  string
+		count func(path string) int
+	}{
+		{"package-lock.json", countNpmLockPackages},
+		{"pnpm-lock.yaml", countPnpmLockPackages},
+		{"yarn.lock", countYarnLockPackages},
+	}
+
+	for _, c := range counters {
+		path := filepath.Join(projectPath, c.name)
+		if _, err := os.Stat(path); err == nil {
+			return c.name, c.count(path)
+		}
+	}
+	return "", 0
+}
+
+// countNpmLockPackages counts entries in package-lock.json ("packages" in v2+, "dependencies" in v1)
+func countNpmLockPackages(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+
+	var lock struct {
+		Packages     map[string]json.RawMessage `json:"packages"`
+		Dependencies map[string]json.RawMessage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return 0
+	}
+
+	if len(lock.Packages) > 0 {
+		// The "" key is the root project itself
+		if _, ok := lock.Packages[""]; ok {
+			return len(lock.Packages) - 1
+		}
+		return len(lock.Packages)
+	}
+	return len(lock.Dependencies)
+}
+
+// countPnpmLockPackages counts the keys of the top-level "packages:" section in pnpm-lock.yaml
+func countPnpmLockPackages(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	count := 0
+	inPackages := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			inPackages = strings.TrimSpace(line) == "packages:"
+			continue
+		}
+		if inPackages && strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") {
+			count++
+		}
+	}
+	return count
+}
+
+// countYarnLockPackages counts the unindented entry headers in yarn.lock
+func countYarnLockPackages(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Yarn Berry records its own metadata as an entry
+		if strings.HasSuffix(line, ":") && !strings.HasPrefix(line, "__metadata") {
+			count++
+		}
+	}
+	return count
+}
+
+// formatDiskSize formats a byte count as MB or GB
+func formatDiskSize(bytes uint64) string {
+	const (
+		mb = 1024 * 1024
+		gb = 1024 * mb
+	)
+	if bytes >= gb {
+		return fmt.Sprintf("%.1f GB", float64(bytes)/gb)
+	}
+	return fmt.Sprintf("%.0f MB", float64(bytes)/mb)
+}
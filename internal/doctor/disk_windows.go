@@ -0,0 +1,8 @@
+//go:build windows
+
+package doctor
+
+// availableDiskSpace is not implemented on this platform, so the disk space check is skipped
+func availableDiskSpace(path string) (uint64, bool) {
+	return 0, false
+}
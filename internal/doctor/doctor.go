@@ -1,6 +1,7 @@
 package doctor
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -36,6 +37,8 @@ type Diagnosis struct {
 	Language     string
 	Runtime      RuntimeStatus
 	Dependencies DependencyStatus
+	DiskSpace    *DiskSpaceStatus // Set when dependencies still need to be installed from a lock file
+	Registry     *RegistryStatus  // Set when Node dependencies still need to be downloaded
 	Healthy      bool
 	Issues       []string
 }
@@ -60,6 +63,9 @@ func Diagnose(projectPath string, language string) Diagnosis {
 	case "Java":
 		diagnosis.Runtime = checkJavaRuntime()
 		diagnosis.Dependencies = checkJavaDependencies(projectPath)
+	case "Kotlin":
+		diagnosis.Runtime = checkKotlinRuntime()
+		diagnosis.Dependencies = checkJavaDependencies(projectPath)
 	case "Go":
 		diagnosis.Runtime = checkGoRuntime()
 		diagnosis.Dependencies = checkGoDependencies(projectPath)
@@ -69,6 +75,9 @@ func Diagnose(projectPath string, language string) Diagnosis {
 	case "Rust":
 		diagnosis.Runtime = checkRustRuntime()
 		diagnosis.Dependencies = checkRustDependencies(projectPath)
+	case "Swift":
+		diagnosis.Runtime = checkSwiftRuntime()
+		diagnosis.Dependencies = checkSwiftDependencies(projectPath)
 	case "HTML":
 		// HTML projects don't need a runtime - they run in the browser
 		diagnosis.Runtime = RuntimeStatus{Name: "Browser", Installed: true, Version: "default"}
@@ -93,6 +102,31 @@ func Diagnose(projectPath string, language string) Diagnosis {
 	if !diagnosis.Dependencies.Installed && diagnosis.Dependencies.ConfigFile != "" {
 		diagnosis.Healthy = false
 		diagnosis.Issues = append(diagnosis.Issues, "Dependencies are not installed")
+
+		// A fresh install in a large monorepo can need several GB
+		if language == "Node" {
+			diagnosis.DiskSpace = checkDiskSpace(projectPath)
+			diagnosis.Registry = checkRegistry(projectPath)
+		}
+	}
+
+	if ds := diagnosis.DiskSpace; ds != nil && !ds.Sufficient {
+		diagnosis.Healthy = false
+		diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf(
+			"Low disk space: %s free, but installing %d packages from %s needs about %s (keep at least %s free)",
+			formatDiskSize(ds.Available), ds.PackageCount, ds.LockFile,
+			formatDiskSize(ds.EstimatedSize), formatDiskSize(ds.EstimatedSize*diskSpaceSafetyFactor)))
+	}
+
+	if rs := diagnosis.Registry; rs != nil && !rs.Reachable {
+		diagnosis.Healthy = false
+		target := "Package registry " + rs.Registry
+		if rs.ViaProxy {
+			target = "Proxy " + rs.Address + " for " + rs.Registry
+		}
+		diagnosis.Issues = append(diagnosis.Issues, fmt.Sprintf(
+			"%s is unreachable (%s). Check your VPN connection, proxy settings (HTTPS_PROXY) and the registry in .npmrc",
+			target, rs.Error))
 	}
 
 	return diagnosis
@@ -143,6 +177,11 @@ func checkPythonRuntime() RuntimeStatus {
 	return status
 }
 
+// JavaRuntime reports the local Java installation, which Kotlin projects also run on
+func JavaRuntime() RuntimeStatus {
+	return checkJavaRuntime()
+}
+
 // checkJavaRuntime checks if Java is installed
 func checkJavaRuntime() RuntimeStatus {
 	status := RuntimeStatus{Name: "Java", Installed: false}
@@ -188,6 +227,27 @@ func checkGoRuntime() RuntimeStatus {
 	return status
 }
 
+// checkKotlinRuntime checks if the Kotlin compiler is installed
+func checkKotlinRuntime() RuntimeStatus {
+	status := RuntimeStatus{Name: "Kotlin", Installed: false}
+
+	// kotlin -version prints to stderr, e.g. "Kotlin version 1.9.22-release-704 (JRE 17.0.9+9)"
+	cmd := exec.Command("kotlin", "-version")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		status.Installed = true
+		status.Version = strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	}
+
+	pathCmd := exec.Command("which", "kotlin")
+	pathOutput, err := pathCmd.Output()
+	if err == nil {
+		status.Path = strings.TrimSpace(string(pathOutput))
+	}
+
+	return status
+}
+
 // checkRubyRuntime checks if Ruby is installed
 func checkRubyRuntime() RuntimeStatus {
 	status := RuntimeStatus{Name: "Ruby", Installed: false}
@@ -228,6 +288,27 @@ func checkRustRuntime() RuntimeStatus {
 	return status
 }
 
+// checkSwiftRuntime checks if the Swift toolchain is installed
+func checkSwiftRuntime() RuntimeStatus {
+	status := RuntimeStatus{Name: "Swift", Installed: false}
+
+	cmd := exec.Command("swift", "--version")
+	output, err := cmd.Output()
+	if err == nil {
+		status.Installed = true
+		// First line holds the version, e.g. "Swift version 5.9.2 (swift-5.9.2-RELEASE)"
+		status.Version = strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	}
+
+	pathCmd := exec.Command("which", "swift")
+	pathOutput, err := pathCmd.Output()
+	if err == nil {
+		status.Path = strings.TrimSpace(string(pathOutput))
+	}
+
+	return status
+}
+
 // checkNodeDependencies checks if Node.js dependencies are installed
 func checkNodeDependencies(projectPath string) DependencyStatus {
 	status := DependencyStatus{Manager: "npm", ManagerInstalled: true}
@@ -320,6 +401,11 @@ func checkPythonDependencies(projectPath string) DependencyStatus {
 			if strings.Contains(content, "[tool.poetry]") {
 				status.Manager = "poetry"
 				status.InstallCommand = "poetry install"
+				status.ManagerInstalled = provisioner.IsCommandAvailable("poetry")
+				if !status.ManagerInstalled {
+					status.ManagerHint = "❌ poetry is required but not installed."
+					status.FixCommand = "pipx install poetry"
+				}
 
 				// Check for poetry.lock as indicator of installed deps
 				if _, err := os.Stat(filepath.Join(projectPath, "poetry.lock")); err == nil {
@@ -356,11 +442,13 @@ func checkJavaDependencies(projectPath string) DependencyStatus {
 		return status
 	}
 
-	// Check for Gradle (build.gradle)
-	gradlePath := filepath.Join(projectPath, "build.gradle")
-	if _, err := os.Stat(gradlePath); err == nil {
+	// Check for Gradle (build.gradle or build.gradle.kts)
+	for _, gradleFile := range []string{"build.gradle", "build.gradle.kts"} {
+		if _, err := os.Stat(filepath.Join(projectPath, gradleFile)); err != nil {
+			continue
+		}
 		status.Manager = "gradle"
-		status.ConfigFile = "build.gradle"
+		status.ConfigFile = gradleFile
 
 		// Check for gradlew
 		if _, err := os.Stat(filepath.Join(projectPath, "gradlew")); err == nil {
@@ -413,6 +501,11 @@ func checkRubyDependencies(projectPath string) DependencyStatus {
 
 	status.ConfigFile = "Gemfile"
 	status.InstallCommand = "bundle install"
+	status.ManagerInstalled = provisioner.IsCommandAvailable("bundle")
+	if !status.ManagerInstalled {
+		status.ManagerHint = "❌ bundler is required but not installed."
+		status.FixCommand = "gem install bundler"
+	}
 
 	// Check for Gemfile.lock as indicator
 	lockPath := filepath.Join(projectPath, "Gemfile.lock")
@@ -444,6 +537,27 @@ func checkRustDependencies(projectPath string) DependencyStatus {
 	return status
 }
 
+// checkSwiftDependencies checks if Swift package dependencies have been resolved
+func checkSwiftDependencies(projectPath string) DependencyStatus {
+	status := DependencyStatus{Manager: "swift"}
+
+	packagePath := filepath.Join(projectPath, "Package.swift")
+	if _, err := os.Stat(packagePath); err != nil {
+		return status
+	}
+
+	status.ConfigFile = "Package.swift"
+	status.InstallCommand = "swift package resolve"
+
+	// SwiftPM checks out and builds dependencies under .build/
+	buildPath := filepath.Join(projectPath, ".build")
+	if info, err := os.Stat(buildPath); err == nil && info.IsDir() {
+		status.Installed = true
+	}
+
+	return status
+}
+
 // detectMissingPythonPackages tries to detect missing Python packages
 func detectMissingPythonPackages(projectPath string, reqPath string) []string {
 	var missing []string
@@ -496,8 +610,3 @@ func InstallDependencies(projectPath string, installCommand string) error {
 
 	return cmd.Run()
 }
-
-// VerifyInstallation re-runs diagnostics to verify installation was successful
-func VerifyInstallation(projectPath string, language string) Diagnosis {
-	return Diagnose(projectPath, language)
-}
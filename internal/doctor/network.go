@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"bufio"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultNpmRegistry is where npm, pnpm and yarn install packages from unless configured otherwise
+const defaultNpmRegistry = "https://registry.npmjs.org/"
+
+// registryDialTimeout bounds the registry reachability check
+const registryDialTimeout = 3 * time.Second
+
+// RegistryStatus reports whether the package registry accepts connections
+type RegistryStatus struct {
+	Registry  string // Registry URL from .npmrc or the environment (default registry.npmjs.org)
+	Address   string // host:port that was dialed; the proxy's address when HTTPS_PROXY is set
+	ViaProxy  bool   // Whether Address belongs to a proxy rather than the registry
+	Reachable bool
+	Error     string // Dial error when the registry is unreachable
+}
+
+// checkRegistry does a TCP connect to the project's npm registry (or its proxy).
+// A successful connect doesn't prove installs will work, but a failed one explains
+// why npm install hangs or fails without a clear message.
+func checkRegistry(projectPath string) *RegistryStatus {
+	status := &RegistryStatus{Registry: npmRegistry(projectPath)}
+
+	address, ok := hostPort(status.Registry)
+	if !ok {
+		return nil
+	}
+	if proxy := httpsProxy(); proxy != "" {
+		if proxyAddress, ok := hostPort(proxy); ok {
+			address = proxyAddress
+			status.ViaProxy = true
+		}
+	}
+	status.Address = address
+
+	conn, err := net.DialTimeout("tcp", address, registryDialTimeout)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	conn.Close()
+	status.Reachable = true
+	return status
+}
+
+// npmRegistry returns the configured registry: npm_config_registry, then the project's
+// .npmrc, then ~/.npmrc, falling back to the public npm registry
+func npmRegistry(projectPath string) string {
+	for _, name := range []string{"npm_config_registry", "NPM_CONFIG_REGISTRY"} {
+		if registry := strings.TrimSpace(os.Getenv(name)); registry != "" {
+			return registry
+		}
+	}
+
+	npmrcs := []string{filepath.Join(projectPath, ".npmrc")}
+	if home, err := os.UserHomeDir(); err == nil {
+		npmrcs = append(npmrcs, filepath.Join(home, ".npmrc"))
+	}
+	for _, path := range npmrcs {
+		if registry := readNpmrcRegistry(path); registry != "" {
+			return registry
+		}
+	}
+	return defaultNpmRegistry
+}
+
+// readNpmrcRegistry returns the unscoped registry= setting from an .npmrc file
+func readNpmrcRegistry(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if found && strings.TrimSpace(key) == "registry" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// httpsProxy returns the proxy npm uses for HTTPS requests, if any
+func httpsProxy() string {
+	for _, name := range []string{"npm_config_https_proxy", "HTTPS_PROXY", "https_proxy"} {
+		if proxy := strings.TrimSpace(os.Getenv(name)); proxy != "" {
+			return proxy
+		}
+	}
+	return ""
+}
+
+// hostPort turns a URL into a dialable host:port, defaulting the port from the scheme
+func hostPort(rawURL string) (string, bool) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), true
+}
@@ -0,0 +1,131 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// goBuildTimeout limits how long the post-install `go build` check may take
+const goBuildTimeout = 2 * time.Minute
+
+// nodeResolveTimeout limits how long resolving the Node dependencies may take
+const nodeResolveTimeout = 30 * time.Second
+
+// maxReportedBuildErrors caps how many compiler output lines are reported
+const maxReportedBuildErrors = 10
+
+// nodeResolveScript prints every module name passed as an argument that require.resolve cannot find.
+// ESM-only packages that don't export a CommonJS entry are installed, so they count as resolved.
+const nodeResolveScript = `for (const name of process.argv.slice(1)) {
+  try { require.resolve(name) } catch (e) {
+    if (e.code !== 'ERR_PACKAGE_PATH_NOT_EXPORTED') console.log(name)
+  }
+}`
+
+// VerifyInstallation re-runs diagnostics and then checks that the installed dependencies
+// are usable: Go projects must build, and the dependencies of Node projects must resolve.
+// This catches installs that report success while e.g. a native addon failed to build.
+func VerifyInstallation(projectPath string, language string) Diagnosis {
+	diagnosis := Diagnose(projectPath, language)
+	if !diagnosis.Dependencies.Installed {
+		return diagnosis
+	}
+
+	var problems []string
+	switch language {
+	case "Go":
+		problems = verifyGoBuild(projectPath)
+	case "Node":
+		if missing := verifyNodeDependencies(projectPath); len(missing) > 0 {
+			diagnosis.Dependencies.MissingPackages = missing
+			problems = []string{"Dependencies cannot be resolved: " + strings.Join(missing, ", ")}
+		}
+	}
+
+	if len(problems) > 0 {
+		diagnosis.Healthy = false
+		diagnosis.Dependencies.Installed = false
+		diagnosis.Issues = append(diagnosis.Issues, problems...)
+	}
+	return diagnosis
+}
+
+// verifyGoBuild compiles every package in the module and returns the compiler errors, if any.
+// Binaries go to the null device so the check never writes into the project.
+func verifyGoBuild(projectPath string) []string {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), goBuildTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", os.DevNull, "./...")
+	cmd.Dir = projectPath
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return []string{fmt.Sprintf("go build did not finish within %s", goBuildTimeout)}
+	}
+	if err == nil {
+		return nil
+	}
+
+	var problems []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		// "# pkg" headers only say which package the following errors belong to
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if len(problems) == maxReportedBuildErrors {
+			problems = append(problems, "...")
+			break
+		}
+		problems = append(problems, "go build: "+strings.TrimSpace(line))
+	}
+	if len(problems) == 0 {
+		problems = append(problems, fmt.Sprintf("go build failed: %v", err))
+	}
+	return problems
+}
+
+// verifyNodeDependencies returns the package.json dependencies that Node cannot resolve
+func verifyNodeDependencies(projectPath string) []string {
+	if _, err := exec.LookPath("node"); err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil || len(pkg.Dependencies) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(pkg.Dependencies))
+	for name := range pkg.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeResolveTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "node", append([]string{"-e", nodeResolveScript}, names...)...)
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(output))
+}
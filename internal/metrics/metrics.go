@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// DefaultPort is the default port for the metrics endpoint (node_exporter convention)
+const DefaultPort = 9100
+
+// Server exposes Prometheus-compatible metrics about the managed process.
+// Metrics are rendered in the Prometheus text exposition format so no client
+// library is required. Process CPU and memory come from gopsutil, which the
+// dashboard's resource view already uses, so they work where there is no /proc.
+type Server struct {
+	port      int
+	srv       *http.Server
+	mu        sync.Mutex
+	pid       int
+	appPort   int
+	restarts  int
+	startTime time.Time
+}
+
+// NewServer creates a metrics server that will listen on the given port
+func NewServer(port int) *Server {
+	if port <= 0 {
+		port = DefaultPort
+	}
+	return &Server{port: port}
+}
+
+// Start begins serving /metrics in the background.
+// It returns an error if the port cannot be bound.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(s.port))
+	if err != nil {
+		return fmt.Errorf("failed to start metrics server on port %d: %w", s.port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	s.srv = &http.Server{Handler: mux}
+
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("⚠️  Warning: metrics server stopped: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop shuts down the metrics server
+func (s *Server) Stop() {
+	if s.srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	s.srv.Shutdown(ctx)
+}
+
+// URL returns the address of the metrics endpoint
+func (s *Server) URL() string {
+	return fmt.Sprintf("http://localhost:%d/metrics", s.port)
+}
+
+// SetProcess records the PID of the managed process.
+// Every call after the first counts as a restart.
+func (s *Server) SetProcess(pid int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pid != 0 {
+		s.restarts++
+	}
+	s.pid = pid
+	s.startTime = time.Now()
+}
+
+// SetPort records the port the managed process is listening on
+func (s *Server) SetPort(port int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appPort = port
+}
+
+// handleMetrics renders the current metrics in Prometheus text format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	pid := s.pid
+	appPort := s.appPort
+	restarts := s.restarts
+	startTime := s.startTime
+	s.mu.Unlock()
+
+	cpuPercent, rss := processTreeUsage(pid)
+
+	uptime := 0.0
+	if !startTime.IsZero() {
+		uptime = time.Since(startTime).Seconds()
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeMetric(w, "octo_process_cpu_percent", "gauge", "CPU usage of the managed process and its children.", cpuPercent)
+	writeMetric(w, "octo_process_resident_memory_bytes", "gauge", "Resident memory of the managed process and its children.", float64(rss))
+	writeMetric(w, "octo_process_restarts_total", "counter", "Number of times the managed process was restarted.", float64(restarts))
+	writeMetric(w, "octo_process_uptime_seconds", "gauge", "Seconds since the managed process was last started.", uptime)
+	writeMetric(w, "octo_process_port", "gauge", "Port the managed process is listening on (0 if unknown).", float64(appPort))
+}
+
+// writeMetric writes a single metric with its HELP and TYPE lines
+func writeMetric(w http.ResponseWriter, name, kind, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+	fmt.Fprintf(w, "%s %s\n", name, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// processTreeUsage sums CPU and resident memory for a process and all of its descendants.
+// Dev servers are usually launched through a shell, so the interesting work
+// happens in child processes.
+func processTreeUsage(pid int) (float64, uint64) {
+	if pid <= 0 {
+		return 0, 0
+	}
+
+	root, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return 0, 0
+	}
+
+	var cpuPercent float64
+	var rss uint64
+
+	queue := []*process.Process{root}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if c, err := p.CPUPercent(); err == nil {
+			cpuPercent += c
+		}
+		if mem, err := p.MemoryInfo(); err == nil && mem != nil {
+			rss += mem.RSS
+		}
+		if children, err := p.Children(); err == nil {
+			queue = append(queue, children...)
+		}
+	}
+
+	return cpuPercent, rss
+}
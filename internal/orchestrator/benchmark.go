@@ -0,0 +1,292 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/ports"
+)
+
+// ==========================================
+// Startup Benchmarking (--benchmark)
+// ==========================================
+
+// benchmarkTimeout bounds how long we wait for the app to become ready
+const benchmarkTimeout = 2 * time.Minute
+
+// benchmarkPollInterval is how often the health check and detected URL are polled
+const benchmarkPollInterval = 100 * time.Millisecond
+
+// benchmarkURLPattern finds local URLs announced in the process output
+var benchmarkURLPattern = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0):\d+`)
+
+// BenchmarkResult is one startup measurement, stored as a line in ~/.octo/benchmarks/<project>.jsonl
+type BenchmarkResult struct {
+	Project        string    `json:"project"`
+	Timestamp      time.Time `json:"timestamp"`
+	Command        string    `json:"command"`
+	URL            string    `json:"url,omitempty"`
+	FirstOutputMs  int64     `json:"first_output_ms,omitempty"`
+	HealthCheckMs  int64     `json:"health_check_ms,omitempty"`
+	FirstHTTP200Ms int64     `json:"first_http_200_ms,omitempty"`
+}
+
+// startupBenchmark measures how long a process takes to become ready
+type startupBenchmark struct {
+	project     string
+	command     string
+	healthCheck string
+	logf        func(string)
+
+	mu          sync.Mutex
+	start       time.Time
+	url         string
+	firstOutput time.Duration
+	healthy     time.Duration
+	firstOK     time.Duration
+	done        chan struct{}
+	finishOnce  sync.Once
+}
+
+// newStartupBenchmark creates a benchmark for the given command.
+// logf is used for the summary so the same code serves plain and dashboard mode.
+func (o *Orchestrator) newStartupBenchmark(command string, logf func(string)) *startupBenchmark {
+	if !o.opts.Benchmark {
+		return nil
+	}
+
+	b := &startupBenchmark{
+		project:     o.bp.Name,
+		command:     command,
+		healthCheck: o.bp.HealthCheck,
+		logf:        logf,
+		done:        make(chan struct{}),
+	}
+	if portInfo := ports.ExtractPort(command); portInfo.Found {
+		b.url = fmt.Sprintf("http://localhost:%d", portInfo.Port)
+	}
+	return b
+}
+
+// setBenchmark replaces the running benchmark. Output goroutines read it while
+// the dashboard's restart loop replaces it, so it is guarded by procMu.
+func (o *Orchestrator) setBenchmark(b *startupBenchmark) {
+	o.procMu.Lock()
+	defer o.procMu.Unlock()
+	o.benchmark = b
+}
+
+// currentBenchmark returns the running benchmark, or nil without --benchmark
+func (o *Orchestrator) currentBenchmark() *startupBenchmark {
+	o.procMu.Lock()
+	defer o.procMu.Unlock()
+	return o.benchmark
+}
+
+// Start records the process start time and begins polling for readiness
+func (b *startupBenchmark) Start() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.start = time.Now()
+	b.mu.Unlock()
+
+	go b.poll()
+}
+
+// Stop ends the benchmark early (e.g. the process exited) and prints what was measured
+func (b *startupBenchmark) Stop() {
+	if b == nil {
+		return
+	}
+	b.finish()
+}
+
+// Output wraps w so the first write is recorded as the first log line
+func (b *startupBenchmark) Output(w io.Writer) io.Writer {
+	if b == nil {
+		return w
+	}
+	return benchmarkWriter{b: b, w: w}
+}
+
+// ObserveLine records output seen by the process, including any URL it announces
+func (b *startupBenchmark) ObserveLine(line string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.start.IsZero() {
+		return
+	}
+	if b.firstOutput == 0 {
+		b.firstOutput = time.Since(b.start)
+	}
+	if b.url == "" {
+		if match := benchmarkURLPattern.FindString(line); match != "" {
+			b.url = strings.Replace(match, "://0.0.0.0:", "://localhost:", 1)
+		}
+	}
+}
+
+// poll checks the health check and detected URL until both respond or the timeout hits
+func (b *startupBenchmark) poll() {
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.After(benchmarkTimeout)
+	ticker := time.NewTicker(benchmarkPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-deadline:
+			b.finish()
+			return
+		case <-ticker.C:
+		}
+
+		b.mu.Lock()
+		url := b.url
+		needHealth := b.healthCheck != "" && b.healthy == 0
+		needURL := b.firstOK == 0
+		b.mu.Unlock()
+
+		if needHealth && httpReady(client, b.healthCheck, false) {
+			b.mu.Lock()
+			b.healthy = time.Since(b.start)
+			b.mu.Unlock()
+			needHealth = false
+		}
+		if needURL && url != "" && httpReady(client, url, true) {
+			b.mu.Lock()
+			b.firstOK = time.Since(b.start)
+			b.mu.Unlock()
+			needURL = false
+		}
+
+		if !needHealth && !needURL {
+			b.finish()
+			return
+		}
+	}
+}
+
+// finish prints the summary and saves the result exactly once
+func (b *startupBenchmark) finish() {
+	b.finishOnce.Do(func() {
+		close(b.done)
+
+		b.mu.Lock()
+		result := BenchmarkResult{
+			Project:        b.project,
+			Timestamp:      b.start,
+			Command:        b.command,
+			URL:            b.url,
+			FirstOutputMs:  b.firstOutput.Milliseconds(),
+			HealthCheckMs:  b.healthy.Milliseconds(),
+			FirstHTTP200Ms: b.firstOK.Milliseconds(),
+		}
+		hasHealthCheck := b.healthCheck != ""
+		b.mu.Unlock()
+
+		b.logf("⏱️  Startup benchmark")
+		b.logf(fmt.Sprintf("   %-22s %s", "First log line", formatBenchmarkMs(result.FirstOutputMs)))
+		if hasHealthCheck {
+			b.logf(fmt.Sprintf("   %-22s %s", "Health check passing", formatBenchmarkMs(result.HealthCheckMs)))
+		}
+		urlLabel := "First HTTP 200"
+		if result.URL != "" {
+			urlLabel += " (" + result.URL + ")"
+		}
+		b.logf(fmt.Sprintf("   %-22s %s", urlLabel, formatBenchmarkMs(result.FirstHTTP200Ms)))
+
+		if path, err := saveBenchmarkResult(result); err != nil {
+			b.logf(fmt.Sprintf("⚠️  Warning: failed to save benchmark: %v", err))
+		} else {
+			b.logf(fmt.Sprintf("   Saved to %s", path))
+		}
+	})
+}
+
+// formatBenchmarkMs formats a duration in milliseconds, or "n/a" if it was never reached
+func formatBenchmarkMs(ms int64) string {
+	if ms <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%dms", ms)
+}
+
+// httpReady reports whether url responds successfully.
+// When exactOK is set only 200 counts; otherwise any 2xx status does.
+func httpReady(client *http.Client, url string, exactOK bool) bool {
+	resp, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	if exactOK {
+		return resp.StatusCode == http.StatusOK
+	}
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// saveBenchmarkResult appends the result to ~/.octo/benchmarks/<project>.jsonl
+func saveBenchmarkResult(result BenchmarkResult) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, ".octo", "benchmarks")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	name := result.Project
+	if name == "" {
+		name = "project"
+	}
+	path := filepath.Join(dir, strings.ReplaceAll(name, string(filepath.Separator), "_")+".jsonl")
+
+	line, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// benchmarkWriter forwards output while reporting it to the benchmark
+type benchmarkWriter struct {
+	b *startupBenchmark
+	w io.Writer
+}
+
+func (bw benchmarkWriter) Write(p []byte) (int, error) {
+	bw.b.ObserveLine(string(p))
+	return bw.w.Write(p)
+}
@@ -0,0 +1,126 @@
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+)
+
+// ==========================================
+// Docker Compose Services (--with-compose)
+// ==========================================
+
+// startCompose runs `docker compose up -d` before setup when docker_compose.start_on_run is set
+// or --with-compose is passed. The returned function stops the services octo started, leaving
+// services that were already running alone, and should be deferred.
+func (o *Orchestrator) startCompose(workDir string, output io.Writer, logf func(string)) (func(), error) {
+	if !o.bp.DockerCompose.StartOnRun && !o.opts.WithCompose {
+		return func() {}, nil
+	}
+
+	file := o.bp.DockerCompose.File
+	if file == "" {
+		file = blueprint.FindComposeFile(workDir)
+		if file == "" {
+			return nil, fmt.Errorf("no Compose file found in %s (looked for %v)", workDir, blueprint.ComposeFileNames)
+		}
+	} else if !filepath.IsAbs(file) {
+		file = filepath.Join(workDir, file)
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("docker is required to start the Compose services: %w", err)
+	}
+
+	// Remember what is already up so only the services started here are stopped on exit
+	targets := o.bp.DockerCompose.Services
+	if len(targets) == 0 {
+		all, err := o.composeServiceNames(workDir, "compose", "-f", file, "config", "--services")
+		if err != nil {
+			return nil, fmt.Errorf("docker compose config failed: %w", err)
+		}
+		targets = all
+	}
+	running, err := o.composeServiceNames(workDir, "compose", "-f", file, "ps", "--services", "--status", "running")
+	if err != nil {
+		logf(fmt.Sprintf("⚠️  Warning: cannot tell which Compose services are running (%v); they will be left running on exit", err))
+		running = targets
+	}
+	started := composeStarted(targets, running)
+
+	logf(fmt.Sprintf("🐳 Starting Compose services from %s...", filepath.Base(file)))
+	args := append([]string{"compose", "-f", file, "up", "-d"}, o.bp.DockerCompose.Services...)
+	if err := o.runCompose(workDir, output, args...); err != nil {
+		return nil, fmt.Errorf("docker compose up failed: %w", err)
+	}
+	logf("✅ Compose services started")
+
+	return func() {
+		if len(started) == 0 {
+			logf("🐳 Leaving Compose services running (they were running before octo started)")
+			return
+		}
+		logf("🐳 Stopping Compose services...")
+		for _, args := range composeTeardown(file, started, len(running) == 0) {
+			if err := o.runCompose(workDir, output, args...); err != nil {
+				logf(fmt.Sprintf("⚠️  Warning: docker compose %s failed: %v", args[3], err))
+				return
+			}
+		}
+	}, nil
+}
+
+// composeServiceNames runs a docker compose command that lists service names, one per line
+func (o *Orchestrator) composeServiceNames(workDir string, args ...string) ([]string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = workDir
+	cmd.Stdout = &out
+	if err := o.runTracked(cmd); err != nil {
+		return nil, err
+	}
+	return strings.Fields(out.String()), nil
+}
+
+// composeStarted returns the targets that were not already running
+func composeStarted(targets []string, running []string) []string {
+	up := make(map[string]bool, len(running))
+	for _, name := range running {
+		up[name] = true
+	}
+
+	var started []string
+	for _, name := range targets {
+		if !up[name] {
+			started = append(started, name)
+		}
+	}
+	return started
+}
+
+// composeTeardown returns the docker commands that undo `up` for the started services.
+// When nothing was running before, the whole project is taken down, networks included;
+// otherwise only the started services are stopped and removed.
+func composeTeardown(file string, started []string, nothingWasRunning bool) [][]string {
+	if nothingWasRunning {
+		return [][]string{{"compose", "-f", file, "down"}}
+	}
+	return [][]string{
+		append([]string{"compose", "-f", file, "stop"}, started...),
+		append([]string{"compose", "-f", file, "rm", "-f"}, started...),
+	}
+}
+
+// runCompose runs docker with args, streaming its output
+func (o *Orchestrator) runCompose(workDir string, output io.Writer, args ...string) error {
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = workDir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	return o.runTracked(cmd)
+}
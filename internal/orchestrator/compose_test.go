@@ -0,0 +1,47 @@
+package orchestrator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComposeTeardown(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		running []string
+		want    [][]string
+	}{
+		{
+			name:    "nothing was running",
+			targets: []string{"postgres", "redis"},
+			want:    [][]string{{"compose", "-f", "dc.yml", "down"}},
+		},
+		{
+			name:    "some were already running",
+			targets: []string{"postgres", "redis"},
+			running: []string{"postgres"},
+			want: [][]string{
+				{"compose", "-f", "dc.yml", "stop", "redis"},
+				{"compose", "-f", "dc.yml", "rm", "-f", "redis"},
+			},
+		},
+		{
+			name:    "all were already running",
+			targets: []string{"postgres", "redis"},
+			running: []string{"redis", "postgres"},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		started := composeStarted(tt.targets, tt.running)
+		var got [][]string
+		if len(started) > 0 {
+			got = composeTeardown("dc.yml", started, len(tt.running) == 0)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: teardown = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
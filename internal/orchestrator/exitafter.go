@@ -0,0 +1,70 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"syscall"
+)
+
+// ==========================================
+// Exit On Log Match (--exit-after)
+// ==========================================
+
+// observeExitAfter stops the run once an output line matches --exit-after.
+// The run then ends successfully, so CI can wait for a startup message and move on.
+func (o *Orchestrator) observeExitAfter(line string) {
+	if o.opts.ExitAfter == nil || !o.opts.ExitAfter.MatchString(line) {
+		return
+	}
+	// Stop from a new goroutine: the caller is the output reader the process may be blocked on
+	o.exitAfterOnce.Do(func() {
+		go o.stopAfterMatch(line)
+	})
+}
+
+// stopAfterMatch gracefully stops every process started by this run
+func (o *Orchestrator) stopAfterMatch(line string) {
+	message := fmt.Sprintf("🏁 Matched --exit-after pattern, stopping: %s", strings.TrimSpace(line))
+
+	if o.dashboard == nil {
+		o.stopRunning(syscall.SIGTERM, message)
+		return
+	}
+
+	o.procMu.Lock()
+	o.stopping = true
+	o.procMu.Unlock()
+
+	o.dashboard.Broadcast(message)
+	for i := 0; i < o.dashboard.GetProjectCount(); i++ {
+		if project := o.dashboard.GetProject(i); project != nil {
+			project.GracefulStop()
+		}
+	}
+}
+
+// exitAfterOutput wraps w so every complete line written through it is checked against --exit-after
+func (o *Orchestrator) exitAfterOutput(w io.Writer) io.Writer {
+	if o.opts.ExitAfter == nil {
+		return w
+	}
+	return &exitAfterWriter{o: o, w: w}
+}
+
+// exitAfterWriter forwards output while splitting it into lines for observeExitAfter
+type exitAfterWriter struct {
+	o       *Orchestrator
+	w       io.Writer
+	partial string
+}
+
+func (ew *exitAfterWriter) Write(p []byte) (int, error) {
+	lines := strings.Split(ew.partial+string(p), "\n")
+	// The last element is an incomplete line (or "" after a trailing newline)
+	ew.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		ew.o.observeExitAfter(strings.TrimRight(line, "\r"))
+	}
+	return ew.w.Write(p)
+}
@@ -0,0 +1,31 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// ==========================================
+// Export PID (--export-pid)
+// ==========================================
+
+// exportPID starts the run command without waiting for it and prints only its PID to stdout.
+// The process gets a fresh context and its own session so it keeps running after octo exits.
+// Its stdin, stdout and stderr go to the null device: an inherited stdout would keep
+// `$(octo run --export-pid)` from returning until the app exits.
+func (o *Orchestrator) exportPID(workDir string, command string, env []string) error {
+	cmd := o.shellCommand(context.Background(), command)
+	cmd.Dir = workDir
+	cmd.Env = env
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+	o.recordProcess(cmd, command)
+
+	fmt.Fprintln(os.Stdout, cmd.Process.Pid)
+	return cmd.Process.Release()
+}
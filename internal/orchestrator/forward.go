@@ -0,0 +1,170 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ==========================================
+// Port Forwarding (--forward-port)
+// ==========================================
+
+// PortForward exposes a local port on another address, e.g. so phones on the LAN can reach a dev server
+type PortForward struct {
+	ListenAddr string // host:port to accept connections on
+	LocalPort  int    // Port on localhost the connections are proxied to
+}
+
+// ParsePortForward parses an <external-host:external-port:local-port> spec such as 0.0.0.0:8080:3000
+func ParsePortForward(spec string) (PortForward, error) {
+	idx := strings.LastIndex(spec, ":")
+	if idx == -1 {
+		return PortForward{}, fmt.Errorf("invalid --forward-port %q (expected external-host:external-port:local-port)", spec)
+	}
+
+	listenAddr, local := spec[:idx], spec[idx+1:]
+	if _, port, err := net.SplitHostPort(listenAddr); err != nil || !validPort(port) {
+		return PortForward{}, fmt.Errorf("invalid --forward-port %q: bad external address %q", spec, listenAddr)
+	}
+	if !validPort(local) {
+		return PortForward{}, fmt.Errorf("invalid --forward-port %q: bad local port %q", spec, local)
+	}
+
+	localPort, _ := strconv.Atoi(local)
+	return PortForward{ListenAddr: listenAddr, LocalPort: localPort}, nil
+}
+
+// validPort reports whether s is a TCP port number
+func validPort(s string) bool {
+	port, err := strconv.Atoi(s)
+	return err == nil && port > 0 && port < 65536
+}
+
+// startPortForwards starts a proxy for every --forward-port: a TCP proxy, or an HTTP
+// reverse proxy when --rate-limit or --latency shape each request.
+// The returned function closes the listeners and every proxied connection.
+func (o *Orchestrator) startPortForwards(logf func(string)) func() {
+	if len(o.opts.ForwardPorts) == 0 {
+		return func() {}
+	}
+
+	var listeners []net.Listener
+	var servers []*http.Server
+	tracker := &connTracker{conns: make(map[net.Conn]struct{})}
+	shaper := o.newTrafficShaper()
+	for _, fwd := range o.opts.ForwardPorts {
+		listener, err := net.Listen("tcp", fwd.ListenAddr)
+		if err != nil {
+			logf(fmt.Sprintf("⚠️  Warning: cannot forward %s: %v", fwd.ListenAddr, err))
+			continue
+		}
+		logf(fmt.Sprintf("🔀 Forwarding %s -> localhost:%d", listener.Addr(), fwd.LocalPort))
+		target := net.JoinHostPort("localhost", strconv.Itoa(fwd.LocalPort))
+		if shaper != nil {
+			server := &http.Server{
+				Handler:  shaper.Handler(&url.URL{Scheme: "http", Host: target}),
+				ErrorLog: log.New(io.Discard, "", 0),
+			}
+			servers = append(servers, server)
+			go server.Serve(listener)
+			continue
+		}
+		listeners = append(listeners, listener)
+		go acceptForwarded(listener, target, tracker)
+	}
+	if shaper != nil {
+		logf(fmt.Sprintf("🐢 Shaping forwarded HTTP requests (rate limit: %s, latency: %s)", describeRateLimit(o.opts.RateLimit), o.opts.Latency))
+	}
+
+	return func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+		for _, server := range servers {
+			server.Close()
+		}
+		tracker.closeAll()
+	}
+}
+
+// acceptForwarded proxies every connection accepted on listener to target until the listener closes
+func acceptForwarded(listener net.Listener, target string, tracker *connTracker) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go proxyConn(conn, target, tracker)
+	}
+}
+
+// proxyConn copies data in both directions between conn and target until either side closes
+func proxyConn(conn net.Conn, target string, tracker *connTracker) {
+	defer conn.Close()
+
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		// The app is not listening (yet); the client sees a closed connection
+		return
+	}
+	defer upstream.Close()
+
+	if !tracker.add(conn, upstream) {
+		return
+	}
+	defer tracker.remove(conn, upstream)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// connTracker remembers open proxied connections so they can be closed when the run ends
+type connTracker struct {
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func (t *connTracker) add(conns ...net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	for _, c := range conns {
+		t.conns[c] = struct{}{}
+	}
+	return true
+}
+
+func (t *connTracker) remove(conns ...net.Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range conns {
+		delete(t.conns, c)
+	}
+}
+
+func (t *connTracker) closeAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	for c := range t.conns {
+		c.Close()
+	}
+}
@@ -0,0 +1,43 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePortForward(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    PortForward
+		wantErr string
+	}{
+		{"0.0.0.0:8080:3000", PortForward{ListenAddr: "0.0.0.0:8080", LocalPort: 3000}, ""},
+		{"localhost:9000:5173", PortForward{ListenAddr: "localhost:9000", LocalPort: 5173}, ""},
+		{":8080:3000", PortForward{ListenAddr: ":8080", LocalPort: 3000}, ""},
+		{"[::1]:8080:3000", PortForward{ListenAddr: "[::1]:8080", LocalPort: 3000}, ""},
+		{"3000", PortForward{}, "expected external-host:external-port:local-port"},
+		{"8080:3000", PortForward{}, `bad external address "8080"`},
+		{"0.0.0.0:http:3000", PortForward{}, `bad external address "0.0.0.0:http"`},
+		{"0.0.0.0:70000:3000", PortForward{}, `bad external address "0.0.0.0:70000"`},
+		{"0.0.0.0:8080:", PortForward{}, `bad local port ""`},
+		{"0.0.0.0:8080:0", PortForward{}, `bad local port "0"`},
+		{"0.0.0.0:8080:web", PortForward{}, `bad local port "web"`},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePortForward(tt.spec)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParsePortForward(%q) error = %v, want it to contain %q", tt.spec, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePortForward(%q) returned error: %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePortForward(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
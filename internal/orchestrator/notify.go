@@ -0,0 +1,94 @@
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ==========================================
+// Desktop Notifications (--notify)
+// ==========================================
+
+// observeNotify sends the ready notification the first time an output line announces a local URL.
+// The dashboard uses its own URL detection instead (see notifyReady).
+func (o *Orchestrator) observeNotify(line string) {
+	if !o.opts.Notify {
+		return
+	}
+	match := benchmarkURLPattern.FindString(line)
+	if match == "" {
+		return
+	}
+	o.notifyReady(strings.Replace(match, "://0.0.0.0:", "://localhost:", 1))
+}
+
+// notifyReady sends a desktop notification saying the server is up at url, once per run
+func (o *Orchestrator) notifyReady(url string) {
+	if !o.opts.Notify || url == "" {
+		return
+	}
+	o.notifyOnce.Do(func() {
+		// Don't block the output reader on the notification command
+		go sendDesktopNotification(fmt.Sprintf("🐙 %s is ready", o.bp.Name), url)
+	})
+}
+
+// sendDesktopNotification shows a native notification. Failures are ignored: a missing
+// notify-send or BurntToast module shouldn't interrupt the run.
+func sendDesktopNotification(title, url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s",
+			appleScriptString("Open "+url), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf("New-BurntToastNotification -Text %s, %s -Button (New-BTButton -Content 'Open' -Arguments %s)",
+			powerShellString(title), powerShellString(url), powerShellString(url))
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return
+		}
+		cmd = exec.Command("notify-send", "--app-name=octo", title, url)
+	}
+	_ = cmd.Run()
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// powerShellString quotes s as a single-quoted PowerShell string literal
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// notifyOutput wraps w so every complete line written through it is checked for the server URL
+func (o *Orchestrator) notifyOutput(w io.Writer) io.Writer {
+	if !o.opts.Notify {
+		return w
+	}
+	return &lineObserverWriter{observe: o.observeNotify, w: w}
+}
+
+// lineObserverWriter forwards output while passing each complete line to observe
+type lineObserverWriter struct {
+	observe func(string)
+	w       io.Writer
+	partial string
+}
+
+func (lw *lineObserverWriter) Write(p []byte) (int, error) {
+	lines := strings.Split(lw.partial+string(p), "\n")
+	// The last element is an incomplete line (or "" after a trailing newline)
+	lw.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		lw.observe(strings.TrimRight(line, "\r"))
+	}
+	return lw.w.Write(p)
+}
@@ -4,15 +4,20 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/harshul/octo-cli/internal/analyzer"
 	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/metrics"
 	"github.com/harshul/octo-cli/internal/ports"
 	"github.com/harshul/octo-cli/internal/provisioner"
 	"github.com/harshul/octo-cli/internal/secrets"
@@ -37,9 +42,37 @@ type Options struct {
 	Detach        bool
 	PortOverride  int  // If > 0, use this port instead of config default
 	NoPortShift   bool // If true, disable automatic port shifting
+	SkipPortCheck bool // If true, don't check whether the port is busy at all (implies NoPortShift)
 	SkipSetup     bool // If true, skip the setup phase
 	SkipEnvCheck  bool // If true, skip environment variable validation
 	UseDashboard  bool // If true, use TUI dashboard instead of scrolling output
+	Concurrency   int  // If > 0, use this worker count and skip thermal detection
+	Services      []string // If set, only start these services from the blueprint
+	MetricsPort   int      // If > 0, expose Prometheus metrics for the process on this port
+	Shell         string   // Shell used to run commands on POSIX systems (default "sh")
+	PIDFile       string   // If set, write the running process PID to this path
+	SkipDoppler   bool     // If true, never load secrets from the Doppler CLI
+	Benchmark     bool     // If true, measure and record startup timings
+	WaitFor       []string      // host:port addresses that must accept connections before running
+	WaitTimeout   time.Duration // How long to wait for WaitFor addresses (default 60s)
+	URLTemplate   string        // Custom dashboard URL format with {project}, {port}, {host} and {path} tokens
+	ExitAfter     *regexp.Regexp // If set, stop the app and exit successfully once an output line matches
+	Quiet         bool           // If true, hide octo's own output and show only the app's
+	StrictEnvValidation bool     // If true, fail when a required env var is unset instead of prompting
+	NoBrowser     bool           // If true, print the URL of HTML projects instead of opening a browser
+	Theme         string         // Dashboard color theme (default, solarized, nord)
+	NoMonorepoLink bool          // If true, skip linking pnpm and bun workspace packages before running
+	ForwardPorts  []PortForward  // External addresses proxied to local ports for the lifetime of the run
+	Notify        bool           // If true, send a desktop notification once the server URL is detected
+	SkipBuildIfRecent time.Duration // If > 0, skip auto-build when the binary is this recent and newer than its sources
+	WithCompose   bool           // If true, start the Compose services before setup even without docker_compose.start_on_run
+	RateLimit     float64        // If > 0, HTTP requests per second let through --forward-port
+	Latency       time.Duration  // Delay added to every HTTP request through --forward-port
+	PrintCommand  bool           // If true, only resolve commands for PrintCommand; never install dependencies
+	WatchCommand  string         // Command run with the app's environment after each change in watch mode, before the restart
+	ExportPID     bool           // If true, start the run command in the background, print its PID and return without waiting
+	MaxLogLines   int            // Log lines each dashboard project keeps (0 = ui.DefaultMaxLogLines)
+	MaxLogBytes   int            // If > 0, prune the oldest dashboard log lines once all projects together exceed this size
 }
 
 type Orchestrator struct {
@@ -50,24 +83,48 @@ type Orchestrator struct {
 	concurrency int
 	batchSize   int
 	dashboard   *ui.DashboardRunner // Optional TUI dashboard
+	services    []blueprint.Service // Services selected via --services
+	procfile    bool                // Services came from the Procfile because no run command is configured
+	metrics     *metrics.Server     // Optional Prometheus metrics endpoint
+	session     *ports.OctoSession  // Registry entry listed by `octo ps`
+
+	procMu     sync.Mutex
+	running    map[*exec.Cmd]chan struct{} // Child processes Stop forwards signals to
+	stopping   bool                        // Set once Stop has been called
+	stopCtx    context.Context             // Cancelled by Stop, for steps that wait without a child process
+	cancelStop context.CancelFunc
+	benchmark  *startupBenchmark           // Startup timings for --benchmark, replaced on restart
+
+	exitAfterOnce sync.Once // Guards the --exit-after shutdown
+	notifyOnce    sync.Once // Guards the --notify ready notification
+	out           io.Writer // octo's own status lines (discarded with --quiet)
 }
 
 func New(bp blueprint.Blueprint, opts Options) (*Orchestrator, error) {
+	// Validate the shell up front so we fail before any setup work
+	if opts.Shell == "" {
+		opts.Shell = "sh"
+	}
+	if runtime.GOOS != "windows" {
+		if _, err := exec.LookPath(opts.Shell); err != nil {
+			return nil, fmt.Errorf("shell %q not found: %w", opts.Shell, err)
+		}
+	}
+
 	// Detect hardware for thermal management
 	hwInfo := thermal.DetectHardware()
 
-	// Determine concurrency based on hardware and config
-	concurrency := thermal.GetOptimalConcurrency(hwInfo, bp.Thermal.Concurrency)
+	// Determine concurrency based on hardware and config.
+	// An explicit --concurrency override bypasses the thermal heuristic entirely.
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = strategyConcurrency(hwInfo, bp.Thermal)
+	}
 
-	// If thermal mode is "performance", use all cores
-	if bp.Thermal.Mode == "performance" {
-		concurrency = hwInfo.NumCPU
-	} else if bp.Thermal.Mode == "cool" {
-		// In "cool" mode, be more conservative
-		concurrency = hwInfo.NumCPU / 2
-		if concurrency < 1 {
-			concurrency = 1
-		}
+	// Without a run command, fall back to the processes declared in a Procfile
+	services, fromProcfile, err := bp.ResolveServices(opts.WorkDir, opts.Services)
+	if err != nil {
+		return nil, err
 	}
 
 	o := &Orchestrator{
@@ -77,16 +134,37 @@ func New(bp blueprint.Blueprint, opts Options) (*Orchestrator, error) {
 		hwInfo:      hwInfo,
 		concurrency: concurrency,
 		batchSize:   bp.Thermal.BatchSize,
+		services:    services,
+		procfile:    fromProcfile,
+		out:         statusOutput(opts.Quiet),
 	}
+	o.stopCtx, o.cancelStop = context.WithCancel(context.Background())
 
 	// Initialize dashboard if requested
 	if opts.UseDashboard {
 		projects := []*ui.Project{
 			ui.NewProject(bp.Name, opts.WorkDir),
 		}
+		// Give each selected service its own panel
+		if len(services) > 0 {
+			projects = projects[:0]
+			for _, svc := range services {
+				projects = append(projects, ui.NewProject(svc.Name, filepath.Join(opts.WorkDir, svc.Path)))
+			}
+		}
+		for _, p := range projects {
+			p.SetURLTemplate(opts.URLTemplate)
+			if opts.Notify {
+				p.OnURLDetected(o.notifyReady)
+			}
+		}
 		o.dashboard = ui.NewDashboardRunner(ui.DashboardConfig{
 			Projects:       projects,
 			MaxConcurrency: concurrency,
+			ThemeName:      opts.Theme,
+
+			MaxLogLines:      opts.MaxLogLines,
+			MaxTotalLogBytes: opts.MaxLogBytes,
 		})
 	}
 
@@ -100,11 +178,17 @@ var runtimeCommands = map[string]string{
 	"javascript": "node",
 	"typescript": "node",
 	"java":       "java",
+	"kotlin":     "kotlin",
 	"python":     "python3",
 	"go":         "go",
 	"golang":     "go",
 	"ruby":       "ruby",
 	"rust":       "cargo",
+	"swift":      "swift",
+	"make":       "make",
+	"jupyter":    "jupyter",
+	"bundler":    "bundle",
+	"rush":       "rush",
 }
 
 // checkRuntime checks if the required runtime is available on the host machine.
@@ -113,16 +197,48 @@ func (o *Orchestrator) checkRuntime() {
 		return
 	}
 
+	// Runtimes installed with asdf are not on PATH when octo starts outside an asdf-enabled shell
+	projectDir := o.opts.WorkDir
+	if projectDir == "" {
+		projectDir, _ = os.Getwd()
+	}
+	provisioner.AddAsdfToolPaths(projectDir)
+	if provisioner.AsdfNodeVersion(projectDir) != "" {
+		provisioner.AddAsdfShimPath()
+	}
+
 	lang := strings.ToLower(o.bp.Language)
+	name := o.bp.Language
+	// Notebook projects need the jupyter CLI, not just the Python interpreter
+	if strings.HasPrefix(strings.TrimSpace(o.bp.RunCommand), "jupyter ") {
+		lang, name = "jupyter", "Jupyter"
+	}
+	// Ruby apps run through Bundler, which is installed separately from the interpreter
+	if lang == "ruby" && strings.HasPrefix(strings.TrimSpace(o.bp.RunCommand), "bundle ") {
+		if _, err := provisioner.LookPath("ruby"); err != nil {
+			fmt.Fprintf(o.out, "⚠️  Warning: %s not found. Please install it.\n", name)
+			return
+		}
+		lang, name = "bundler", "Bundler"
+	}
+	// Rush monorepos run their commands through the rush CLI on top of Node
+	if o.bp.PackageManager == "rush" && runtimeCommands[lang] == "node" {
+		if _, err := provisioner.LookPath("node"); err != nil {
+			fmt.Fprintf(o.out, "⚠️  Warning: %s not found. Please install it.\n", name)
+			return
+		}
+		lang, name = "rush", "Rush"
+	}
+
 	runtimeCmd, ok := runtimeCommands[lang]
 	if !ok {
 		// Unknown language, skip the check
 		return
 	}
 
-	_, err := exec.LookPath(runtimeCmd)
+	_, err := provisioner.LookPath(runtimeCmd)
 	if err != nil {
-		fmt.Printf("⚠️  Warning: %s not found. Please install it.\n", o.bp.Language)
+		fmt.Fprintf(o.out, "⚠️  Warning: %s not found. Please install it.\n", name)
 	}
 }
 
@@ -134,55 +250,210 @@ func (o *Orchestrator) displayThermalInfo() {
 	}
 
 	hwDesc := thermal.FormatHardwareInfo(o.hwInfo)
-	fmt.Printf("🖥️  Hardware: %s\n", hwDesc)
+	fmt.Fprintf(o.out, "🖥️  Hardware: %s\n", hwDesc)
 
 	// Determine what mode we're running in
-	modeDesc := "auto"
+	modeDesc := blueprint.StrategyAuto
 	if o.bp.Thermal.Mode != "" {
 		modeDesc = o.bp.Thermal.Mode
 	}
 
 	// Show concurrency info
-	if o.hwInfo.IsMacBookAir && modeDesc != "performance" {
-		fmt.Printf("🌡️  Thermal mode: %s (MacBook Air detected - reduced concurrency for quiet operation)\n", modeDesc)
-	} else if o.hwInfo.IsDarwin && o.hwInfo.IsAppleSilicon && modeDesc != "performance" {
-		fmt.Printf("🌡️  Thermal mode: %s (Apple Silicon - optimized concurrency)\n", modeDesc)
+	if modeDesc == blueprint.StrategyManual {
+		fmt.Fprintf(o.out, "🌡️  Thermal mode: %s (using configured concurrency, batch size and cool-down)\n", modeDesc)
+	} else if o.hwInfo.IsMacBookAir && modeDesc != blueprint.StrategyPerformance {
+		fmt.Fprintf(o.out, "🌡️  Thermal mode: %s (MacBook Air detected - reduced concurrency for quiet operation)\n", modeDesc)
+	} else if o.hwInfo.IsDarwin && o.hwInfo.IsAppleSilicon && modeDesc != blueprint.StrategyPerformance {
+		fmt.Fprintf(o.out, "🌡️  Thermal mode: %s (Apple Silicon - optimized concurrency)\n", modeDesc)
 	}
 
-	fmt.Printf("⚡ Concurrency: %d workers\n", o.concurrency)
+	fmt.Fprintf(o.out, "⚡ Concurrency: %d workers\n", o.concurrency)
 
 	// Check current thermal status on macOS
-	if o.hwInfo.IsDarwin && (modeDesc == "auto" || modeDesc == "cool") {
-		status := thermal.GetThermalStatus(o.hwInfo)
-		if status.Level != "cool" {
-			fmt.Printf("🌡️  Thermal status: %s - %s\n", status.Level, status.Message)
+	if warning := o.thermalWarning(); warning != "" {
+		fmt.Fprintln(o.out, warning)
+	}
+}
+
+// thermalWarning returns a warning if the machine is currently running hot (macOS only).
+// It returns an empty string when there is nothing to report.
+func (o *Orchestrator) thermalWarning() string {
+	mode := o.bp.Thermal.Mode
+	if mode == "" {
+		mode = blueprint.StrategyAuto
+	}
+	if !o.hwInfo.IsDarwin || (mode != blueprint.StrategyAuto && mode != blueprint.StrategyBalanced && mode != blueprint.StrategyCool) {
+		return ""
+	}
+
+	status := thermal.GetThermalStatus(o.hwInfo)
+	if status.Level == "cool" {
+		return ""
+	}
+	return fmt.Sprintf("🌡️  Thermal status: %s - %s", status.Level, status.Message)
+}
+
+// shellCommand builds a command that runs the given command line through the configured shell
+func (o *Orchestrator) shellCommand(ctx context.Context, command string) *exec.Cmd {
+	shell, flag := o.shellInvocation()
+	return exec.CommandContext(ctx, shell, flag, command)
+}
+
+// shellInvocation returns the shell commands run in and the flag that passes it a command line.
+// Windows always uses cmd /C; elsewhere the --shell option (default "sh") is used.
+func (o *Orchestrator) shellInvocation() (string, string) {
+	if runtime.GOOS == "windows" {
+		return "cmd", "/C"
+	}
+	if o.opts.Shell == "" {
+		return "sh", "-c"
+	}
+	return o.opts.Shell, "-c"
+}
+
+// startMetrics starts the metrics endpoint if --metrics was requested.
+// The returned function tears it down again.
+func (o *Orchestrator) startMetrics() func() {
+	if o.opts.MetricsPort <= 0 {
+		return func() {}
+	}
+
+	o.metrics = metrics.NewServer(o.opts.MetricsPort)
+	if err := o.metrics.Start(); err != nil {
+		fmt.Fprintf(o.out, "⚠️  Warning: %v\n", err)
+		o.metrics = nil
+		return func() {}
+	}
+
+	if o.dashboard != nil {
+		o.logToDashboard(0, fmt.Sprintf("📈 Metrics available at %s", o.metrics.URL()))
+	} else {
+		fmt.Fprintf(o.out, "📈 Metrics available at %s\n", o.metrics.URL())
+	}
+	return o.metrics.Stop
+}
+
+// recordProcess reports a newly started process to the PID file and metrics endpoint
+func (o *Orchestrator) recordProcess(cmd *exec.Cmd, command string) {
+	if cmd.Process == nil {
+		return
+	}
+
+	o.writePIDFile(cmd.Process.Pid)
+
+	if o.metrics == nil {
+		return
+	}
+
+	o.metrics.SetProcess(cmd.Process.Pid)
+	if portInfo := ports.ExtractPort(command); portInfo.Found {
+		o.metrics.SetPort(portInfo.Port)
+	}
+}
+
+// writePIDFile writes the process PID to the --pid-file path, if one was given
+func (o *Orchestrator) writePIDFile(pid int) {
+	if o.opts.PIDFile == "" {
+		return
+	}
+
+	if err := os.WriteFile(o.opts.PIDFile, []byte(fmt.Sprintf("%d\n", pid)), 0644); err != nil {
+		msg := fmt.Sprintf("⚠️  Warning: failed to write PID file %s: %v", o.opts.PIDFile, err)
+		if o.dashboard != nil {
+			o.logToDashboard(0, msg)
+		} else {
+			fmt.Fprintln(o.out, msg)
+		}
+	}
+}
+
+// removePIDFile deletes the --pid-file once the orchestrator is done.
+// With --export-pid the app outlives octo, so its PID file is kept.
+func (o *Orchestrator) removePIDFile() {
+	if o.opts.PIDFile == "" || o.opts.ExportPID {
+		return
+	}
+	os.Remove(o.opts.PIDFile)
+}
+
+// registerSession records this run in ~/.octo/pids so `octo ps` can list it.
+// The returned function removes the entry again.
+func (o *Orchestrator) registerSession() func() {
+	session, err := ports.RegisterOctoProcess(o.bp.Name, o.opts.WorkDir)
+	if err != nil {
+		return func() {}
+	}
+	o.session = session
+	return session.Close
+}
+
+// strategyConcurrency returns the worker count for the configured thermal mode
+func strategyConcurrency(hwInfo thermal.HardwareInfo, cfg blueprint.ThermalConfig) int {
+	var concurrency int
+	switch cfg.Mode {
+	case blueprint.StrategyPerformance:
+		// Use all cores
+		concurrency = hwInfo.NumCPU
+	case blueprint.StrategyBalanced:
+		concurrency = hwInfo.NumCPU * 3 / 4
+	case blueprint.StrategyCool:
+		// Be more conservative: only the efficiency cores on Apple Silicon
+		concurrency = thermal.CoolConcurrency(hwInfo)
+	case blueprint.StrategyManual:
+		concurrency = cfg.Concurrency
+		if concurrency <= 0 {
+			concurrency = hwInfo.NumCPU
 		}
+	default:
+		concurrency = thermal.GetOptimalConcurrency(hwInfo, cfg.Concurrency)
 	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return concurrency
 }
 
 // injectConcurrencyFlags adds concurrency flags to supported tools in the command
 func (o *Orchestrator) injectConcurrencyFlags(command string) string {
 	// Skip if performance mode - let tools use their defaults
-	if o.bp.Thermal.Mode == "performance" {
+	if o.bp.Thermal.Mode == blueprint.StrategyPerformance {
 		return command
 	}
 
 	return thermal.InjectConcurrencyFlag(command, o.concurrency)
 }
 
+// Run executes the setup and run phases in the foreground.
+// If Stop interrupts a step before the app is running, Run returns errStopped after its cleanup.
 func (o *Orchestrator) Run() error {
-	fmt.Printf("🚀 Starting %s (env=%s, build=%v, watch=%v, detach=%v)\n",
+	err := o.run()
+	if err != nil && o.stopRequested() {
+		return errStopped
+	}
+	return err
+}
+
+func (o *Orchestrator) run() error {
+	fmt.Fprintf(o.out, "🚀 Starting %s (env=%s, build=%v, watch=%v, detach=%v)\n",
 		o.bp.Name, o.opts.Environment, o.opts.RunBuild, o.opts.Watch, o.opts.Detach)
 
 	// Display thermal/hardware info
 	o.displayThermalInfo()
 
-	// Handle options that are currently not implemented to avoid silently ignoring them.
-	if o.opts.Watch {
-		fmt.Println("⚠️  Warning: Watch option is not implemented yet; changes will not be watched automatically.")
+	// Expose process metrics for the lifetime of the run
+	defer o.startMetrics()()
+	defer o.startPortForwards(func(line string) { fmt.Fprintln(o.out, line) })()
+	defer o.removePIDFile()
+	defer o.registerSession()()
+
+	if o.opts.ExportPID && len(o.services) > 0 {
+		return fmt.Errorf("--export-pid starts a single process and cannot be used with services")
 	}
+
+	// Handle options that are currently not implemented to avoid silently ignoring them.
 	if o.opts.Detach {
-		fmt.Println("⚠️  Warning: Detach option is not implemented yet; the process will run in the foreground.")
+		fmt.Fprintln(o.out, "⚠️  Warning: Detach option is not implemented yet; the process will run in the foreground.")
 	}
 	// Check if the required runtime is available
 	o.checkRuntime()
@@ -197,24 +468,32 @@ func (o *Orchestrator) Run() error {
 		// Use monorepo root as the working directory
 		if info, err := os.Stat(o.bp.MonorepoRoot); err == nil && info.IsDir() {
 			workDir = o.bp.MonorepoRoot
-			fmt.Printf("📂 Using monorepo root: %s\n", workDir)
+			fmt.Fprintf(o.out, "📂 Using monorepo root: %s\n", workDir)
 		} else {
-			fmt.Printf("⚠️  Warning: monorepo_root %s does not exist, using current directory\n", o.bp.MonorepoRoot)
+			fmt.Fprintf(o.out, "⚠️  Warning: monorepo_root %s does not exist, using current directory\n", o.bp.MonorepoRoot)
 		}
 	}
 
 	// ==========================================
-	// PHASE 0: Monorepo Linking (for pnpm workspaces)
+	// PHASE 0: Monorepo Linking (for pnpm and bun workspaces)
 	// ==========================================
-	if o.bp.IsMonorepo && o.bp.PackageManager == "pnpm" {
+	if o.bp.IsMonorepo && o.bp.PackageManager == "pnpm" && !o.opts.NoMonorepoLink {
 		if err := o.ensurePnpmWorkspaceLinked(workDir); err != nil {
-			fmt.Printf("⚠️  Warning: pnpm workspace linking failed: %v\n", err)
+			fmt.Fprintf(o.out, "⚠️  Warning: pnpm workspace linking failed: %v\n", err)
+		}
+	}
+	if o.bp.IsMonorepo && o.bp.PackageManager == "bun" && !o.opts.NoMonorepoLink {
+		if err := o.ensureBunWorkspaceLinked(workDir); err != nil {
+			fmt.Fprintf(o.out, "⚠️  Warning: bun workspace linking failed: %v\n", err)
 		}
 	}
 
 	// Check and install dependencies if needed (e.g., node_modules for Node projects)
 	if err := o.checkAndInstallDependencies(workDir); err != nil {
-		fmt.Printf("⚠️  Warning: dependency check failed: %v\n", err)
+		fmt.Fprintf(o.out, "⚠️  Warning: dependency check failed: %v\n", err)
+	}
+	if o.stopRequested() {
+		return errStopped
 	}
 
 	// Check environment variables (unless skipped)
@@ -227,33 +506,52 @@ func (o *Orchestrator) Run() error {
 		o.loadEnvVarsForInjection(workDir)
 	}
 
+	// Start databases and other Compose services before setup, which may run migrations
+	stopCompose, err := o.startCompose(workDir, o.commandOutput(), func(line string) { fmt.Fprintln(o.out, line) })
+	if err != nil {
+		return err
+	}
+	defer stopCompose()
+
 	// ==========================================
 	// PHASE 1: Setup Phase (Mandatory Pre-Run)
 	// ==========================================
 	if o.bp.SetupRequired && o.bp.SetupCommand != "" && !o.opts.SkipSetup {
-		fmt.Println("\n📋 ═══════════════════════════════════════════════")
-		fmt.Println("   PHASE 1: Setup (Mandatory Pre-Run)")
-		fmt.Println("   ═══════════════════════════════════════════════")
-		fmt.Printf("   Command: %s\n", o.bp.SetupCommand)
-		fmt.Println("   ═══════════════════════════════════════════════")
-		fmt.Println()
+		fmt.Fprintln(o.out, "\n📋 ═══════════════════════════════════════════════")
+		fmt.Fprintln(o.out, "   PHASE 1: Setup (Mandatory Pre-Run)")
+		fmt.Fprintln(o.out, "   ═══════════════════════════════════════════════")
+		fmt.Fprintf(o.out, "   Command: %s\n", o.bp.SetupCommand)
+		fmt.Fprintln(o.out, "   ═══════════════════════════════════════════════")
+		fmt.Fprintln(o.out)
 
 		if err := o.executeSetupPhase(workDir, o.bp.SetupCommand); err != nil {
 			return fmt.Errorf("setup phase failed (this is a mandatory step): %w", err)
 		}
 
-		fmt.Println("\n✅ Setup phase completed successfully!")
-		fmt.Println()
+		fmt.Fprintln(o.out, "\n✅ Setup phase completed successfully!")
+		fmt.Fprintln(o.out)
 	}
 
 	// ==========================================
 	// PHASE 2: Run Phase
 	// ==========================================
 	if o.bp.SetupRequired && o.bp.SetupCommand != "" && !o.opts.SkipSetup {
-		fmt.Println("📋 ═══════════════════════════════════════════════")
-		fmt.Println("   PHASE 2: Run")
-		fmt.Println("   ═══════════════════════════════════════════════")
-		fmt.Println()
+		fmt.Fprintln(o.out, "📋 ═══════════════════════════════════════════════")
+		fmt.Fprintln(o.out, "   PHASE 2: Run")
+		fmt.Fprintln(o.out, "   ═══════════════════════════════════════════════")
+		fmt.Fprintln(o.out)
+	}
+
+	// Wait for dependencies (databases, other services) before starting
+	if err := o.waitForDependencies(func(line string) {
+		fmt.Fprintln(o.out, line)
+	}); err != nil {
+		return err
+	}
+
+	// Run only the selected services when --services is provided
+	if len(o.services) > 0 {
+		return o.runServices(workDir, o.services)
 	}
 
 	// Check if we have a run command
@@ -264,6 +562,11 @@ func (o *Orchestrator) Run() error {
 	// Start with the configured run command
 	runCommand := o.bp.RunCommand
 
+	// Install tools like air that the run command depends on
+	runCommand = o.ensureRunTool(workDir, runCommand, o.commandOutput(), func(line string) {
+		fmt.Fprintln(o.out, line)
+	})
+
 	// Auto-build logic: If run command references a local binary (./), check for build requirements
 	if err := o.autoBuildIfNeeded(workDir, runCommand); err != nil {
 		return fmt.Errorf("auto-build failed: %w", err)
@@ -274,47 +577,11 @@ func (o *Orchestrator) Run() error {
 	
 	// Handle port override if specified (skip for HTML projects)
 	if !isHTMLProject {
-		// First, check if there's already a process on the target port
-		portInfo := ports.ExtractPort(runCommand)
-		if portInfo.Found {
-			if processOnPort := o.checkProcessOnPort(portInfo.Port); processOnPort {
-				if !o.opts.NoPortShift {
-					// Find an available port and shift
-					newPort := ports.FindAvailablePort(portInfo.Port + 1)
-					if newPort > 0 {
-						fmt.Printf("⚠️  Port %d already has a running process. Shifting to %d.\n", portInfo.Port, newPort)
-						runCommand = ports.ShiftPort(runCommand, portInfo.Port, newPort)
-					} else {
-						fmt.Printf("⚠️  Port %d is busy and no available ports found nearby.\n", portInfo.Port)
-					}
-				} else {
-					fmt.Printf("⚠️  Port %d already has a running process. Use --no-port-shift=false to auto-shift.\n", portInfo.Port)
-				}
-			}
-		}
+		runCommand = o.applyPortHandling(runCommand)
+	}
 
-		if o.opts.PortOverride > 0 {
-			portInfo := ports.ExtractPort(runCommand)
-			if portInfo.Found {
-				runCommand = ports.ShiftPort(runCommand, portInfo.Port, o.opts.PortOverride)
-				fmt.Printf("📌 Using specified port %d\n", o.opts.PortOverride)
-			} else {
-				// No port flag exists, append one based on language
-				runCommand = ports.AppendPortFlag(runCommand, o.bp.Language, o.opts.PortOverride)
-				fmt.Printf("📌 Adding port %d to command\n", o.opts.PortOverride)
-			}
-		} else if !o.opts.NoPortShift {
-			// Check for port conflicts and auto-shift if needed
-			newCommand, newPort, wasShifted, err := ports.CheckAndShift(runCommand)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: %v\n", err)
-			} else if wasShifted {
-				// Extract original port for the message
-				portInfo := ports.ExtractPort(runCommand)
-				fmt.Printf("⚠️  Port %d busy, shifting command to %d.\n", portInfo.Port, newPort)
-				runCommand = newCommand
-			}
-		}
+	if o.stopRequested() {
+		return errStopped
 	}
 
 	// Parse and execute the run command with proper path handling
@@ -326,6 +593,53 @@ func (o *Orchestrator) Run() error {
 	return nil
 }
 
+// applyPortHandling shifts the run command off busy ports, or onto --port when given
+func (o *Orchestrator) applyPortHandling(runCommand string) string {
+	// First, check if there's already a process on the target port
+	portInfo := ports.ExtractPort(runCommand)
+	if portInfo.Found && !o.opts.SkipPortCheck {
+		if processOnPort := o.checkProcessOnPort(portInfo.Port); processOnPort {
+			if !o.opts.NoPortShift {
+				// Find an available port and shift
+				newPort := ports.FindAvailablePort(portInfo.Port + 1)
+				if newPort > 0 {
+					fmt.Fprintf(o.out, "⚠️  Port %d already has a running process. Shifting to %d.\n", portInfo.Port, newPort)
+					runCommand = ports.ShiftPort(runCommand, portInfo.Port, newPort)
+				} else {
+					fmt.Fprintf(o.out, "⚠️  Port %d is busy and no available ports found nearby.\n", portInfo.Port)
+				}
+			} else {
+				fmt.Fprintf(o.out, "⚠️  Port %d already has a running process. Use --no-port-shift=false to auto-shift.\n", portInfo.Port)
+			}
+		}
+	}
+
+	if o.opts.PortOverride > 0 {
+		portInfo := ports.ExtractPort(runCommand)
+		if portInfo.Found {
+			runCommand = ports.ShiftPort(runCommand, portInfo.Port, o.opts.PortOverride)
+			fmt.Fprintf(o.out, "📌 Using specified port %d\n", o.opts.PortOverride)
+		} else {
+			// No port flag exists, append one based on language
+			runCommand = ports.AppendPortFlag(runCommand, o.bp.Language, o.opts.PortOverride)
+			fmt.Fprintf(o.out, "📌 Adding port %d to command\n", o.opts.PortOverride)
+		}
+	} else if !o.opts.NoPortShift {
+		// Check for port conflicts and auto-shift if needed
+		newCommand, newPort, wasShifted, err := ports.CheckAndShift(runCommand)
+		if err != nil {
+			fmt.Fprintf(o.out, "⚠️  Warning: %v\n", err)
+		} else if wasShifted {
+			// Extract original port for the message
+			portInfo := ports.ExtractPort(runCommand)
+			fmt.Fprintf(o.out, "⚠️  Port %d busy, shifting command to %d.\n", portInfo.Port, newPort)
+			runCommand = newCommand
+		}
+	}
+
+	return runCommand
+}
+
 // checkEnvVars verifies environment variables and gives user option to skip.
 // It also attempts to auto-bootstrap from templates if .env files are missing.
 func (o *Orchestrator) checkEnvVars() error {
@@ -339,34 +653,120 @@ func (o *Orchestrator) checkEnvVars() error {
 	// ==========================================
 	bootstrapped, bootstrappedPaths, err := secrets.AutoBootstrapEnvFiles(workDir)
 	if err != nil {
-		fmt.Printf("⚠️  Warning: template detection failed: %v\n", err)
+		fmt.Fprintf(o.out, "⚠️  Warning: template detection failed: %v\n", err)
 	} else if bootstrapped > 0 {
-		fmt.Printf("📋 Auto-bootstrapped %d .env file(s) from templates:\n", bootstrapped)
+		fmt.Fprintf(o.out, "📋 Auto-bootstrapped %d .env file(s) from templates:\n", bootstrapped)
 		for _, path := range bootstrappedPaths {
-			fmt.Printf("   ✅ %s\n", path)
+			fmt.Fprintf(o.out, "   ✅ %s\n", path)
 		}
-		fmt.Println()
+		fmt.Fprintln(o.out)
 	}
 
 	// ==========================================
 	// Step 2: Load all env vars for global injection
 	// ==========================================
 	o.loadEnvVarsForInjection(workDir)
+	o.validateEnvSchema(workDir, func(line string) { fmt.Fprintln(o.out, line) })
 
-	if len(o.bp.EnvVars) == 0 {
+	missingRequired, missingOptional := o.missingEnvVars(workDir)
+
+	// If no missing variables at all, proceed
+	if len(missingRequired) == 0 && len(missingOptional) == 0 {
 		return nil
 	}
 
+	// If only optional variables are missing, just log and proceed
+	if len(missingRequired) == 0 {
+		if len(missingOptional) > 0 {
+			fmt.Fprintf(o.out, "ℹ️  Note: %d optional environment variable(s) not set. Proceeding anyway.\n", len(missingOptional))
+		}
+		return nil
+	}
+
+	// In strict mode there is no one to prompt (CI, scripts); fail instead
+	if o.opts.StrictEnvValidation {
+		return strictEnvError(missingRequired, "drop --env-validate-strict")
+	}
+	// --quiet hides the prompt, so asking would wait on stdin with nothing on screen
+	if o.opts.Quiet {
+		return strictEnvError(missingRequired, "run without --quiet to enter it")
+	}
+
+	// Required variables are missing - give user choice
+	fmt.Fprintf(o.out, "\n⚠️  Missing %d environment variable(s) that may be needed:\n", len(missingRequired))
+	for _, name := range missingRequired {
+		fmt.Fprintf(o.out, "   • %s\n", name)
+	}
+	fmt.Fprintln(o.out)
+
+	// Ask user what they want to do
+	fmt.Fprint(o.out, "Options: [s]kip and run anyway, [p]rovide values, [q]uit? (s/p/q): ")
+	text, err := o.readLine()
+	if err != nil {
+		return err
+	}
+	text = strings.TrimSpace(strings.ToLower(text))
+
+	switch text {
+	case "s", "skip", "":
+		// User chose to skip - proceed without the env vars
+		fmt.Fprintln(o.out, "⏭️  Skipping environment variables. The app may not work correctly.")
+		return nil
+	case "q", "quit", "exit":
+		return fmt.Errorf("aborted by user")
+	case "p", "provide", "y", "yes":
+		// User wants to provide values
+		descriptions := make(map[string]string)
+		for _, name := range missingRequired {
+			descriptions[name] = "Environment variable"
+		}
+
+		values := ui.PromptForSecrets(missingRequired, descriptions)
+
+		// Set the provided values in the current process environment
+		// and add to envVars for global injection
+		for k, v := range values {
+			if v != "" {
+				os.Setenv(k, v)
+				o.envVars[k] = v
+			}
+		}
+
+		fmt.Fprintln(o.out, "✅ Environment variables set for this session.")
+		return nil
+	default:
+		// Unknown input - default to skip
+		fmt.Fprintln(o.out, "⏭️  Skipping environment variables. The app may not work correctly.")
+		return nil
+	}
+}
+
+// missingEnvVars returns the blueprint's env vars that are set neither in the environment,
+// the injected secrets nor the project's .env files, split by whether they are required
+func (o *Orchestrator) missingEnvVars(workDir string) (required []string, optional []string) {
+	// Grouped variables count too; AllEnvVars merges them into the flat list
+	expectedVars := o.bp.AllEnvVars()
+	if len(expectedVars) == 0 {
+		return nil, nil
+	}
+
 	// Build a map of all defined env vars from .env files AND current environment
 	definedVars := make(map[string]bool)
 	
 	// First, check current environment
-	for _, v := range o.bp.EnvVars {
+	for _, v := range expectedVars {
 		if os.Getenv(v.Name) != "" {
 			definedVars[v.Name] = true
 		}
 	}
 	
+	// Secrets injected from Doppler also count as defined
+	for _, v := range expectedVars {
+		if _, ok := o.envVars[v.Name]; ok {
+			definedVars[v.Name] = true
+		}
+	}
+
 	// Then, read from .env files in the project (root + common subdirectories)
 	envFilePaths := []string{
 		filepath.Join(workDir, ".env"),
@@ -387,76 +787,80 @@ func (o *Orchestrator) checkEnvVars() error {
 		}
 	}
 
-	var missingRequired []string
-	var missingOptional []string
-
-	for _, v := range o.bp.EnvVars {
+	for _, v := range expectedVars {
 		if !definedVars[v.Name] {
 			if v.Required {
-				missingRequired = append(missingRequired, v.Name)
+				required = append(required, v.Name)
 			} else {
-				missingOptional = append(missingOptional, v.Name)
+				optional = append(optional, v.Name)
 			}
 		}
 	}
 
-	// If no missing variables at all, proceed
-	if len(missingRequired) == 0 && len(missingOptional) == 0 {
-		return nil
-	}
+	return required, optional
+}
 
-	// If only optional variables are missing, just log and proceed
-	if len(missingRequired) == 0 {
-		if len(missingOptional) > 0 {
-			fmt.Printf("ℹ️  Note: %d optional environment variable(s) not set. Proceeding anyway.\n", len(missingOptional))
-		}
-		return nil
+// readLine reads a line from stdin, giving up with errStopped when Stop is called
+func (o *Orchestrator) readLine() (string, error) {
+	line := make(chan string, 1)
+	go func() {
+		text, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		line <- text
+	}()
+
+	select {
+	case text := <-line:
+		return text, nil
+	case <-o.stopCtx.Done():
+		return "", errStopped
 	}
+}
 
-	// Required variables are missing - give user choice
-	fmt.Printf("\n⚠️  Missing %d environment variable(s) that may be needed:\n", len(missingRequired))
-	for _, name := range missingRequired {
-		fmt.Printf("   • %s\n", name)
+// strictEnvError reports the first missing required env var when octo cannot prompt for it.
+// hint is the alternative to setting it, e.g. "drop --env-validate-strict".
+func strictEnvError(missingRequired []string, hint string) error {
+	if len(missingRequired) > 1 {
+		return fmt.Errorf("required environment variable %s is not set (and %d more); set it or %s",
+			missingRequired[0], len(missingRequired)-1, hint)
 	}
-	fmt.Println()
+	return fmt.Errorf("required environment variable %s is not set; set it or %s", missingRequired[0], hint)
+}
 
-	// Ask user what they want to do
-	fmt.Print("Options: [s]kip and run anyway, [p]rovide values, [q]uit? (s/p/q): ")
-	reader := bufio.NewReader(os.Stdin)
-	text, _ := reader.ReadString('\n')
-	text = strings.TrimSpace(strings.ToLower(text))
+// validateEnvSchema checks env var values against the blueprint's env_var_schema file, if it exists.
+// Violations are reported through logf as warnings; they don't stop the run.
+func (o *Orchestrator) validateEnvSchema(workDir string, logf func(string)) {
+	if o.bp.EnvVarSchema == "" {
+		return
+	}
 
-	switch text {
-	case "s", "skip", "":
-		// User chose to skip - proceed without the env vars
-		fmt.Println("⏭️  Skipping environment variables. The app may not work correctly.")
-		return nil
-	case "q", "quit", "exit":
-		return fmt.Errorf("aborted by user")
-	case "p", "provide", "y", "yes":
-		// User wants to provide values
-		descriptions := make(map[string]string)
-		for _, name := range missingRequired {
-			descriptions[name] = "Environment variable"
-		}
+	path := o.bp.EnvVarSchema
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
 
-		values := ui.PromptForSecrets(missingRequired, descriptions)
+	schema, err := secrets.LoadEnvSchema(path)
+	if err != nil {
+		logf(fmt.Sprintf("⚠️  Warning: %v", err))
+		return
+	}
 
-		// Set the provided values in the current process environment
-		// and add to envVars for global injection
-		for k, v := range values {
-			if v != "" {
-				os.Setenv(k, v)
-				o.envVars[k] = v
-			}
+	// Injected values override the inherited environment, so check them first
+	problems := schema.Validate(func(name string) (string, bool) {
+		if value, ok := o.envVars[name]; ok {
+			return value, true
 		}
+		return os.LookupEnv(name)
+	})
+	if len(problems) == 0 {
+		return
+	}
 
-		fmt.Println("✅ Environment variables set for this session.")
-		return nil
-	default:
-		// Unknown input - default to skip
-		fmt.Println("⏭️  Skipping environment variables. The app may not work correctly.")
-		return nil
+	logf(fmt.Sprintf("⚠️  %d environment variable(s) do not match %s:", len(problems), o.bp.EnvVarSchema))
+	for _, problem := range problems {
+		logf("   • " + problem)
 	}
 }
 
@@ -464,6 +868,9 @@ func (o *Orchestrator) checkEnvVars() error {
 // into command environments. This ensures all phases (Setup, Build, Run) have
 // access to the same environment variables.
 func (o *Orchestrator) loadEnvVarsForInjection(workDir string) {
+	// Doppler secrets take priority over everything else
+	o.loadDopplerSecrets(workDir)
+
 	// Get all env vars from .env files
 	allVars := secrets.GetAllEnvVars(workDir)
 	
@@ -475,7 +882,7 @@ func (o *Orchestrator) loadEnvVarsForInjection(workDir string) {
 	}
 
 	// Also add any env vars from the current environment that match blueprint requirements
-	for _, ev := range o.bp.EnvVars {
+	for _, ev := range o.bp.AllEnvVars() {
 		if val := os.Getenv(ev.Name); val != "" {
 			if _, exists := o.envVars[ev.Name]; !exists {
 				o.envVars[ev.Name] = val
@@ -484,7 +891,36 @@ func (o *Orchestrator) loadEnvVarsForInjection(workDir string) {
 	}
 
 	if len(o.envVars) > 0 {
-		fmt.Printf("🔐 Loaded %d environment variable(s) for global injection\n", len(o.envVars))
+		fmt.Fprintf(o.out, "🔐 Loaded %d environment variable(s) for global injection\n", len(o.envVars))
+	}
+}
+
+// loadDopplerSecrets injects secrets from the Doppler CLI when the project uses Doppler.
+// It can be disabled with --skip-doppler or OCTO_SKIP_DOPPLER=1.
+func (o *Orchestrator) loadDopplerSecrets(workDir string) {
+	if o.opts.SkipDoppler || os.Getenv("OCTO_SKIP_DOPPLER") == "1" {
+		return
+	}
+	if !secrets.DopplerConfigured(workDir) {
+		return
+	}
+
+	var msg string
+	dopplerVars, err := secrets.LoadDopplerSecrets(workDir)
+	if err != nil {
+		msg = fmt.Sprintf("⚠️  Warning: %v", err)
+	} else {
+		for k, v := range dopplerVars {
+			o.envVars[k] = v
+		}
+		msg = fmt.Sprintf("🔑 Loaded %d secret(s) from Doppler", len(dopplerVars))
+	}
+
+	// With the dashboard, secrets are loaded after it has taken over the terminal
+	if o.dashboard != nil {
+		o.logToDashboard(0, msg)
+	} else {
+		fmt.Fprintln(o.out, msg)
 	}
 }
 
@@ -577,15 +1013,15 @@ func (o *Orchestrator) installNodeDependencies(projectPath string, subDir string
 	managerName := provisioner.GetManagerName(pmCheck.Manager)
 	if subDir != "" {
 		if pmCheck.IsMonorepo {
-			fmt.Printf("📦 Detected %s monorepo in %s/. Running %s...\n", managerName, subDir, strings.Join(installCmd, " "))
+			fmt.Fprintf(o.out, "📦 Detected %s monorepo in %s/. Running %s...\n", managerName, subDir, strings.Join(installCmd, " "))
 		} else {
-			fmt.Printf("📦 Detected package.json in %s/ but node_modules is missing. Running %s...\n", subDir, strings.Join(installCmd, " "))
+			fmt.Fprintf(o.out, "📦 Detected package.json in %s/ but node_modules is missing. Running %s...\n", subDir, strings.Join(installCmd, " "))
 		}
 	} else {
 		if pmCheck.IsMonorepo {
-			fmt.Printf("📦 Detected %s monorepo. Running %s...\n", managerName, strings.Join(installCmd, " "))
+			fmt.Fprintf(o.out, "📦 Detected %s monorepo. Running %s...\n", managerName, strings.Join(installCmd, " "))
 		} else {
-			fmt.Printf("📦 Detected package.json but node_modules is missing. Running %s...\n", strings.Join(installCmd, " "))
+			fmt.Fprintf(o.out, "📦 Detected package.json but node_modules is missing. Running %s...\n", strings.Join(installCmd, " "))
 		}
 	}
 
@@ -595,13 +1031,13 @@ func (o *Orchestrator) installNodeDependencies(projectPath string, subDir string
 
 	cmd := exec.CommandContext(ctx, installCmd[0], installCmd[1:]...)
 	cmd.Dir = projectPath
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = o.commandOutput()
 	cmd.Stderr = os.Stderr
 
 	// Use enhanced environment to ensure newly installed binaries are available
 	cmd.Env = provisioner.BuildEnhancedEnvironment()
 
-	if err := cmd.Run(); err != nil {
+	if err := o.runTracked(cmd); err != nil {
 		if subDir != "" {
 			return fmt.Errorf("%s in %s failed: %w", strings.Join(installCmd, " "), subDir, err)
 		}
@@ -609,9 +1045,9 @@ func (o *Orchestrator) installNodeDependencies(projectPath string, subDir string
 	}
 
 	if subDir != "" {
-		fmt.Printf("✅ Dependencies installed successfully in %s/.\n", subDir)
+		fmt.Fprintf(o.out, "✅ Dependencies installed successfully in %s/.\n", subDir)
 	} else {
-		fmt.Println("✅ Dependencies installed successfully.")
+		fmt.Fprintln(o.out, "✅ Dependencies installed successfully.")
 	}
 
 	return nil
@@ -652,30 +1088,34 @@ func (o *Orchestrator) autoBuildIfNeeded(workDir string, runCommand string) erro
 		if !o.opts.RunBuild {
 			return nil
 		}
+		if o.opts.SkipBuildIfRecent > 0 && binaryIsRecent(workDir, fullBinaryPath, o.opts.SkipBuildIfRecent) {
+			fmt.Fprintf(o.out, "⏭️  %s is up to date with its sources (--skip-build-if-recent %s). Skipping build.\n", binaryPath, o.opts.SkipBuildIfRecent)
+			return nil
+		}
 	}
 
-	fmt.Printf("🔨 Local binary %s not found or build requested. Attempting auto-build...\n", binaryPath)
+	fmt.Fprintf(o.out, "🔨 Local binary %s not found or build requested. Attempting auto-build...\n", binaryPath)
 
 	// Check for Makefile
 	makefilePath := filepath.Join(workDir, "Makefile")
 	if _, err := os.Stat(makefilePath); err == nil {
-		fmt.Println("📋 Found Makefile. Running make...")
+		fmt.Fprintln(o.out, "📋 Found Makefile. Running make...")
 		cmd := exec.Command("make")
 		cmd.Dir = workDir
-		cmd.Stdout = os.Stdout
+		cmd.Stdout = o.commandOutput()
 		cmd.Stderr = os.Stderr
 		
-		if err := cmd.Run(); err != nil {
+		if err := o.runTracked(cmd); err != nil {
 			return fmt.Errorf("make failed: %w", err)
 		}
-		fmt.Println("✅ Build completed successfully.")
+		fmt.Fprintln(o.out, "✅ Build completed successfully.")
 		return nil
 	}
 
 	// Check for Go project (go.mod)
 	goModPath := filepath.Join(workDir, "go.mod")
 	if _, err := os.Stat(goModPath); err == nil {
-		fmt.Println("📋 Found go.mod. Running go build...")
+		fmt.Fprintln(o.out, "📋 Found go.mod. Running go build...")
 		
 		// Determine the output binary name
 		outputName := strings.TrimPrefix(binaryPath, "./")
@@ -692,21 +1132,55 @@ func (o *Orchestrator) autoBuildIfNeeded(workDir string, runCommand string) erro
 		}
 		
 		cmd.Dir = workDir
-		cmd.Stdout = os.Stdout
+		cmd.Stdout = o.commandOutput()
 		cmd.Stderr = os.Stderr
 		
-		if err := cmd.Run(); err != nil {
+		if err := o.runTracked(cmd); err != nil {
 			return fmt.Errorf("go build failed: %w", err)
 		}
-		fmt.Println("✅ Build completed successfully.")
+		fmt.Fprintln(o.out, "✅ Build completed successfully.")
 		return nil
 	}
 
 	// No supported build system found
-	fmt.Printf("⚠️  No Makefile or go.mod found. Cannot auto-build %s.\n", binaryPath)
+	fmt.Fprintf(o.out, "⚠️  No Makefile or go.mod found. Cannot auto-build %s.\n", binaryPath)
 	return nil
 }
 
+// ensureRunTool installs the Go tool a run command starts with (e.g. air) if it is missing.
+// If air can't be installed, the command falls back to the binary air would have built,
+// which autoBuildIfNeeded then builds. Installer output goes to output.
+func (o *Orchestrator) ensureRunTool(workDir string, runCommand string, output io.Writer, logf func(string)) string {
+	fields := strings.Fields(runCommand)
+	if len(fields) == 0 || !provisioner.IsGoTool(fields[0]) || provisioner.IsCommandAvailable(fields[0]) {
+		return runCommand
+	}
+
+	tool := fields[0]
+	logf(fmt.Sprintf("📦 %s not found. Installing with go install...", tool))
+	if err := provisioner.InstallGoTool(tool, output); err != nil {
+		logf(fmt.Sprintf("⚠️  Warning: could not install %s: %v", tool, err))
+		if tool == "air" {
+			bin := analyzer.AirBinPath(workDir)
+			logf(fmt.Sprintf("⚠️  Running %s without live reload", bin))
+			return bin
+		}
+		return runCommand
+	}
+
+	logf(fmt.Sprintf("✅ Installed %s", tool))
+	return runCommand
+}
+
+// resolveLocalTools runs CLIs like turbo through npx when they are only installed in node_modules/.bin
+func (o *Orchestrator) resolveLocalTools(workDir string, runCommand string, logf func(string)) string {
+	resolved, pmInfo := provisioner.ResolveLocalTools(workDir, runCommand)
+	if pmInfo.NpxFallback {
+		logf(fmt.Sprintf("📦 Using locally installed tools via %s: %s", provisioner.LocalToolRunner(pmInfo.Manager), resolved))
+	}
+	return resolved
+}
+
 // extractBinaryPath extracts the local binary path from a run command.
 // e.g., "./bin/app --flag" -> "./bin/app"
 //       "make && ./app" -> "./app"
@@ -738,6 +1212,23 @@ func extractBinaryPath(runCommand string) string {
 	return ""
 }
 
+// browserURL returns the address a browser-open command (e.g. "xdg-open index.html") would show.
+// Local files are turned into absolute file:// URLs.
+func browserURL(workDir string, command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	target := strings.Trim(fields[len(fields)-1], `"'`)
+	if strings.Contains(target, "://") {
+		return target
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(workDir, target)
+	}
+	return "file://" + filepath.ToSlash(target)
+}
+
 // executeWithPathCorrection executes a command with proper handling of directory changes.
 // It correctly handles nested commands like "cd frontend && npm start" by
 // resolving the working directory for each sub-command.
@@ -750,6 +1241,9 @@ func (o *Orchestrator) executeWithPathCorrection(workDir string, runCommand stri
 
 	// Inject concurrency flags for thermal management
 	resolvedCommand = o.injectConcurrencyFlags(resolvedCommand)
+	resolvedCommand = o.resolveLocalTools(resolvedWorkDir, resolvedCommand, func(line string) {
+		fmt.Fprintln(o.out, line)
+	})
 
 	// Detect the package manager for this project
 	pmInfo := provisioner.DetectPackageManager(resolvedWorkDir)
@@ -759,7 +1253,7 @@ func (o *Orchestrator) executeWithPathCorrection(workDir string, runCommand stri
 	if o.usesTurbo(resolvedCommand) {
 		// For Turbo, add npm_config_user_agent to help it detect the package manager
 		baseEnv = provisioner.BuildEnhancedEnvironmentWithTurbo(pmInfo.Manager, pmInfo.Version)
-		fmt.Printf("🔧 Turbo detected - setting npm_config_user_agent for %s\n", pmInfo.Manager)
+		fmt.Fprintf(o.out, "🔧 Turbo detected - setting npm_config_user_agent for %s\n", pmInfo.Manager)
 	} else {
 		baseEnv = provisioner.BuildEnhancedEnvironment()
 	}
@@ -770,58 +1264,114 @@ func (o *Orchestrator) executeWithPathCorrection(workDir string, runCommand stri
 	// Log if we're using additional paths
 	additionalPaths := provisioner.GetAdditionalPaths()
 	if len(additionalPaths) > 0 {
-		fmt.Printf("🔗 Injecting %d additional binary path(s) into environment\n", len(additionalPaths))
+		fmt.Fprintf(o.out, "🔗 Injecting %d additional binary path(s) into environment\n", len(additionalPaths))
 	}
 
 	// Parse and execute the run command
 	// Use shell to handle complex commands with pipes, redirects, etc.
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := o.runContext(context.Background())
 	defer cancel()
 
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.CommandContext(ctx, "cmd", "/C", resolvedCommand)
-	} else {
-		cmd = exec.CommandContext(ctx, "sh", "-c", resolvedCommand)
-	}
+	newCmd := func() *exec.Cmd {
+		cmd := o.shellCommand(ctx, resolvedCommand)
 
-	// Set the resolved working directory
-	cmd.Dir = resolvedWorkDir
+		// Set the resolved working directory
+		cmd.Dir = resolvedWorkDir
 
-	// Set the enhanced environment with secrets
-	cmd.Env = env
+		// Set the enhanced environment with secrets
+		cmd.Env = env
+		return cmd
+	}
+	cmd := newCmd()
 
 	// For HTML projects, we just open the browser and exit
+	if isHTMLProject && o.opts.NoBrowser {
+		fmt.Fprintf(o.out, "🌐 Not opening a browser (--no-browser). Open this URL manually: %s\n", browserURL(resolvedWorkDir, resolvedCommand))
+		fmt.Fprintf(o.out, "   or run: %s\n", resolvedCommand)
+		return nil
+	}
 	if isHTMLProject {
-		fmt.Printf("🌐 Opening in browser: %s\n", resolvedCommand)
+		fmt.Fprintf(o.out, "🌐 Opening in browser: %s\n", resolvedCommand)
 		if err := cmd.Start(); err != nil {
 			return fmt.Errorf("failed to open browser: %w", err)
 		}
-		fmt.Println("✅ Opened in default browser!")
+		fmt.Fprintln(o.out, "✅ Opened in default browser!")
 		return nil
 	}
 
+	// --export-pid hands the process to the caller instead of supervising it
+	if o.opts.ExportPID {
+		return o.exportPID(resolvedWorkDir, resolvedCommand, env)
+	}
+
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 
 	if resolvedWorkDir != workDir {
-		fmt.Printf("📂 Working directory: %s\n", resolvedWorkDir)
+		fmt.Fprintf(o.out, "📂 Working directory: %s\n", resolvedWorkDir)
+	}
+	fmt.Fprintf(o.out, "📦 Executing: %s\n", resolvedCommand)
+
+	// In watch mode, restart the command whenever a watched file changes
+	if o.opts.Watch {
+		return o.runWatched(resolvedWorkDir, func() (*exec.Cmd, error) {
+			cmd := newCmd()
+			cmd.Stdout = o.session.Output(os.Stdout)
+			cmd.Stderr = o.session.Output(os.Stderr)
+			// Run in its own process group so restarts also stop child processes
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+			if err := cmd.Start(); err != nil {
+				return cmd, err
+			}
+			o.recordProcess(cmd, resolvedCommand)
+			return cmd, nil
+		}, o.watchCommandBuilder(func() *exec.Cmd {
+			cmd := o.shellCommand(ctx, o.opts.WatchCommand)
+			cmd.Dir = resolvedWorkDir
+			cmd.Env = env
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd
+		}), func(line string) {
+			fmt.Fprintln(o.out, line)
+		})
 	}
-	fmt.Printf("📦 Executing: %s\n", resolvedCommand)
+
+	// Measure startup time if --benchmark was requested
+	benchmark := o.newStartupBenchmark(resolvedCommand, func(line string) {
+		fmt.Fprintln(o.out, line)
+	})
+	o.setBenchmark(benchmark)
+	cmd.Stdout = o.session.Output(benchmark.Output(o.exitAfterOutput(o.notifyOutput(os.Stdout))))
+	cmd.Stderr = o.session.Output(benchmark.Output(o.exitAfterOutput(o.notifyOutput(os.Stderr))))
 
 	// Run the command
-	if err := cmd.Run(); err != nil {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+	benchmark.Start()
+	o.recordProcess(cmd, resolvedCommand)
+	o.trackProcess(cmd)
+	err := cmd.Wait()
+	o.untrackProcess(cmd)
+	benchmark.Stop()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("run command timed out after %s", o.runTimeout())
+	}
+	if err != nil && !o.stopRequested() {
 		return fmt.Errorf("command failed: %w", err)
 	}
 
 	return nil
 }
 
-// usesTurbo checks if the command uses Turbo (turborepo)
+// usesTurbo checks if the command uses Turbo (turborepo), or Turbo was chosen as the workspace runner
+// (package scripts like "dev": "turbo dev" hide it behind "npm run dev")
 func (o *Orchestrator) usesTurbo(command string) bool {
 	lowerCmd := strings.ToLower(command)
-	return strings.Contains(lowerCmd, "turbo") ||
+	return o.bp.WorkspaceRunner == "turbo" ||
+		strings.Contains(lowerCmd, "turbo") ||
 		strings.Contains(lowerCmd, "turbo run") ||
 		strings.Contains(lowerCmd, "turbo build") ||
 		strings.Contains(lowerCmd, "turbo dev")
@@ -855,16 +1405,18 @@ func (o *Orchestrator) resolveNestedCommand(workDir string, runCommand string) (
 					
 					// Verify the directory exists
 					if info, err := os.Stat(resolvedDir); err == nil && info.IsDir() {
-						// Check for dependencies in the new directory
-						if err := o.checkAndInstallDependencies(resolvedDir); err != nil {
-							fmt.Printf("⚠️  Warning: dependency check in %s failed: %v\n", targetDir, err)
+						// Check for dependencies in the new directory (--print-command only shows what would run)
+						if !o.opts.PrintCommand {
+							if err := o.checkAndInstallDependencies(resolvedDir); err != nil {
+								fmt.Fprintf(o.out, "⚠️  Warning: dependency check in %s failed: %v\n", targetDir, err)
+							}
 						}
 						
 						// Recursively resolve any further cd commands in remainder
 						return o.resolveNestedCommand(resolvedDir, remainder)
 					} else {
 						// Directory doesn't exist, return original command
-						fmt.Printf("⚠️  Warning: directory %s does not exist\n", targetDir)
+						fmt.Fprintf(o.out, "⚠️  Warning: directory %s does not exist\n", targetDir)
 						return workDir, runCommand
 					}
 				}
@@ -886,38 +1438,36 @@ func (o *Orchestrator) executeSetupPhase(workDir string, setupCommand string) er
 
 	// Inject concurrency flags for thermal management
 	resolvedCommand = o.injectConcurrencyFlags(resolvedCommand)
+	resolvedCommand = o.resolveLocalTools(resolvedWorkDir, resolvedCommand, func(line string) {
+		fmt.Fprintln(o.out, line)
+	})
 
 	// Build the enhanced environment with all detected secrets injected
 	baseEnv := provisioner.BuildEnhancedEnvironment()
 	env := o.buildEnvWithSecrets(baseEnv)
 
-	// Create a context with a generous timeout for setup (30 minutes for large monorepos)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	// Create a context with a generous timeout for setup (30 minutes unless configured)
+	ctx, cancel := context.WithTimeout(context.Background(), o.setupTimeout())
 	defer cancel()
 
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.CommandContext(ctx, "cmd", "/C", resolvedCommand)
-	} else {
-		cmd = exec.CommandContext(ctx, "sh", "-c", resolvedCommand)
-	}
+	cmd := o.shellCommand(ctx, resolvedCommand)
 
 	cmd.Dir = resolvedWorkDir
 	cmd.Env = env
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = o.commandOutput()
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 
 	if resolvedWorkDir != workDir {
-		fmt.Printf("📂 Working directory: %s\n", resolvedWorkDir)
+		fmt.Fprintf(o.out, "📂 Working directory: %s\n", resolvedWorkDir)
 	}
-	fmt.Printf("🔧 Executing setup: %s\n", resolvedCommand)
+	fmt.Fprintf(o.out, "🔧 Executing setup: %s\n", resolvedCommand)
 
 	// Run the setup command and wait for completion
-	if err := cmd.Run(); err != nil {
+	if err := o.runTracked(cmd); err != nil {
 		// Check if it was a timeout
 		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("setup command timed out after 30 minutes")
+			return fmt.Errorf("setup command timed out after %s", o.setupTimeout())
 		}
 		return fmt.Errorf("setup command exited with error: %w", err)
 	}
@@ -949,22 +1499,68 @@ func (o *Orchestrator) ensurePnpmWorkspaceLinked(workDir string) error {
 		return nil
 	}
 
-	fmt.Println("📦 Detected pnpm workspace. Running pnpm install to link packages...")
+	// pnpm writes .modules.yaml after every install, so its presence also means linked
+	modulesYaml := filepath.Join(workDir, "node_modules", ".modules.yaml")
+	if _, err := os.Stat(modulesYaml); err == nil {
+		return nil
+	}
+
+	fmt.Fprintln(o.out, "📦 Detected pnpm workspace. Running pnpm install to link packages...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "pnpm", "install")
 	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = o.commandOutput()
 	cmd.Stderr = os.Stderr
 	cmd.Env = provisioner.BuildEnhancedEnvironment()
 
-	if err := cmd.Run(); err != nil {
+	if err := o.runTracked(cmd); err != nil {
 		return fmt.Errorf("pnpm install failed: %w", err)
 	}
 
-	fmt.Println("✅ pnpm workspace linked successfully!")
+	fmt.Fprintln(o.out, "✅ pnpm workspace linked successfully!")
+	return nil
+}
+
+// ensureBunWorkspaceLinked ensures that bun workspace packages are linked into node_modules.
+// Bun links workspaces itself, so `bun install` is run once at the root if any package is missing.
+func (o *Orchestrator) ensureBunWorkspaceLinked(workDir string) error {
+	packages := provisioner.BunWorkspacePackages(workDir)
+	if len(packages) == 0 {
+		// Not a bun workspace, nothing to do
+		return nil
+	}
+
+	// Every workspace package is linked as node_modules/<name> (scoped names included)
+	linked := true
+	for name := range packages {
+		if _, err := os.Stat(filepath.Join(workDir, "node_modules", name)); err != nil {
+			linked = false
+			break
+		}
+	}
+	if linked {
+		return nil
+	}
+
+	fmt.Fprintln(o.out, "📦 Detected bun workspace. Running bun install to link packages...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "bun", "install")
+	cmd.Dir = workDir
+	cmd.Stdout = o.commandOutput()
+	cmd.Stderr = os.Stderr
+	cmd.Env = provisioner.BuildEnhancedEnvironment()
+
+	if err := o.runTracked(cmd); err != nil {
+		return fmt.Errorf("bun install failed: %w", err)
+	}
+
+	fmt.Fprintln(o.out, "✅ bun workspace linked successfully!")
 	return nil
 }
 
@@ -1100,8 +1696,9 @@ type BatchProcessor struct {
 func (o *Orchestrator) NewBatchProcessor(totalItems int) *BatchProcessor {
 	batchSize := thermal.GetOptimalBatchSize(o.hwInfo, totalItems, o.batchSize)
 	
+	// Manual mode uses the configured cool-down as-is, including 0 (no delay)
 	coolDownMs := o.bp.Thermal.CoolDownMs
-	if coolDownMs == 0 {
+	if coolDownMs == 0 && o.bp.Thermal.Mode != blueprint.StrategyManual {
 		coolDownMs = thermal.DefaultCoolDownMs
 	}
 
@@ -1158,11 +1755,11 @@ func (o *Orchestrator) ExecuteInBatches(items []string, fn func(item string) err
 	}
 
 	batches := processor.GetBatches(items)
-	fmt.Printf("📦 Processing %d items in %d batches (batch size: %d, cool-down: %dms)\n",
+	fmt.Fprintf(o.out, "📦 Processing %d items in %d batches (batch size: %d, cool-down: %dms)\n",
 		len(items), len(batches), processor.BatchSize, processor.CoolDownMs)
 
 	for i, batch := range batches {
-		fmt.Printf("\n🔄 Batch %d/%d (%d items)\n", i+1, len(batches), len(batch))
+		fmt.Fprintf(o.out, "\n🔄 Batch %d/%d (%d items)\n", i+1, len(batches), len(batch))
 
 		for _, item := range batch {
 			if err := fn(item); err != nil {
@@ -1172,7 +1769,7 @@ func (o *Orchestrator) ExecuteInBatches(items []string, fn func(item string) err
 
 		// Cool down between batches (but not after the last batch)
 		if i < len(batches)-1 {
-			fmt.Printf("🌡️  Cooling down for %dms...\n", processor.CoolDownMs)
+			fmt.Fprintf(o.out, "🌡️  Cooling down for %dms...\n", processor.CoolDownMs)
 			processor.CoolDown()
 		}
 	}
@@ -1186,7 +1783,7 @@ func (o *Orchestrator) GetThermalConfig() thermal.Config {
 		Concurrency: o.concurrency,
 		BatchSize:   o.batchSize,
 		CoolDownMs:  o.bp.Thermal.CoolDownMs,
-		ThermalMode: o.bp.Thermal.Mode,
+		ThermalMode: string(o.bp.Thermal.Mode),
 	}
 }
 
@@ -1266,16 +1863,33 @@ func (o *Orchestrator) runWithDashboardUpdates() error {
 	// Log to dashboard
 	o.logToDashboard(0, fmt.Sprintf("🚀 Starting %s (env=%s)", o.bp.Name, o.opts.Environment))
 
+	// Thermal throttling affects every project, so warn in all panels
+	if warning := o.thermalWarning(); warning != "" {
+		o.dashboard.Broadcast(warning)
+	}
+
+	// Expose process metrics for the lifetime of the run
+	defer o.startMetrics()()
+	defer o.startPortForwards(func(line string) { o.logToDashboard(0, line) })()
+	defer o.removePIDFile()
+	defer o.registerSession()()
+
 	// Check runtime
 	o.checkRuntime()
 
 	// Monorepo linking
-	if o.bp.IsMonorepo && o.bp.PackageManager == "pnpm" {
+	if o.bp.IsMonorepo && o.bp.PackageManager == "pnpm" && !o.opts.NoMonorepoLink {
 		o.logToDashboard(0, "📦 Checking pnpm workspace links...")
 		if err := o.ensurePnpmWorkspaceLinked(workDir); err != nil {
 			o.logToDashboard(0, fmt.Sprintf("⚠️  Warning: pnpm workspace linking failed: %v", err))
 		}
 	}
+	if o.bp.IsMonorepo && o.bp.PackageManager == "bun" && !o.opts.NoMonorepoLink {
+		o.logToDashboard(0, "📦 Checking bun workspace links...")
+		if err := o.ensureBunWorkspaceLinked(workDir); err != nil {
+			o.logToDashboard(0, fmt.Sprintf("⚠️  Warning: bun workspace linking failed: %v", err))
+		}
+	}
 
 	// Check dependencies
 	if err := o.checkAndInstallDependencies(workDir); err != nil {
@@ -1284,6 +1898,24 @@ func (o *Orchestrator) runWithDashboardUpdates() error {
 
 	// Check env vars (skip interactive prompts in dashboard mode)
 	o.loadEnvVarsForInjection(workDir)
+	o.validateEnvSchema(workDir, func(line string) { o.logToDashboard(0, line) })
+	if o.opts.StrictEnvValidation {
+		if missingRequired, _ := o.missingEnvVars(workDir); len(missingRequired) > 0 {
+			err := strictEnvError(missingRequired, "drop --env-validate-strict")
+			o.dashboard.UpdateProject(0, ui.PhaseSetup, ui.StatusError)
+			o.logToDashboard(0, fmt.Sprintf("❌ %v", err))
+			return err
+		}
+	}
+
+	// Start databases and other Compose services before setup, which may run migrations
+	stopCompose, err := o.startCompose(workDir, o.dashboard.GetWriter(0), func(line string) { o.logToDashboard(0, line) })
+	if err != nil {
+		o.dashboard.UpdateProject(0, ui.PhaseSetup, ui.StatusError)
+		o.logToDashboard(0, fmt.Sprintf("❌ %v", err))
+		return err
+	}
+	defer stopCompose()
 
 	// Setup phase
 	if o.bp.SetupRequired && o.bp.SetupCommand != "" && !o.opts.SkipSetup {
@@ -1299,6 +1931,20 @@ func (o *Orchestrator) runWithDashboardUpdates() error {
 		o.logToDashboard(0, "✅ Setup completed successfully")
 	}
 
+	// Wait for dependencies (databases, other services) before starting
+	if err := o.waitForDependencies(func(line string) {
+		o.logToDashboard(0, line)
+	}); err != nil {
+		o.dashboard.UpdateProject(0, ui.PhaseRun, ui.StatusError)
+		o.logToDashboard(0, fmt.Sprintf("❌ %v", err))
+		return err
+	}
+
+	// Run only the selected services when --services is provided
+	if len(o.services) > 0 {
+		return o.runServices(workDir, o.services)
+	}
+
 	// Run phase
 	if o.bp.RunCommand == "" {
 		o.dashboard.UpdateProject(0, ui.PhaseRun, ui.StatusError)
@@ -1308,6 +1954,11 @@ func (o *Orchestrator) runWithDashboardUpdates() error {
 	o.dashboard.UpdateProject(0, ui.PhaseRun, ui.StatusRunning)
 	runCommand := o.bp.RunCommand
 
+	// Install tools like air that the run command depends on
+	runCommand = o.ensureRunTool(workDir, runCommand, o.dashboard.GetWriter(0), func(line string) {
+		o.logToDashboard(0, line)
+	})
+
 	// Auto-build if needed
 	if err := o.autoBuildIfNeeded(workDir, runCommand); err != nil {
 		o.dashboard.UpdateProject(0, ui.PhaseRun, ui.StatusError)
@@ -1346,7 +1997,7 @@ func (o *Orchestrator) handlePortConfiguration(runCommand string) string {
 	portInfo := ports.ExtractPort(runCommand)
 	finalPort := portInfo.Port
 	
-	if portInfo.Found {
+	if portInfo.Found && !o.opts.SkipPortCheck {
 		if processOnPort := o.checkProcessOnPort(portInfo.Port); processOnPort {
 			if !o.opts.NoPortShift {
 				newPort := ports.FindAvailablePort(portInfo.Port + 1)
@@ -1390,19 +2041,17 @@ func (o *Orchestrator) handlePortConfiguration(runCommand string) string {
 func (o *Orchestrator) executeSetupPhaseWithDashboard(workDir string, setupCommand string) error {
 	resolvedWorkDir, resolvedCommand := o.resolveNestedCommand(workDir, setupCommand)
 	resolvedCommand = o.injectConcurrencyFlags(resolvedCommand)
+	resolvedCommand = o.resolveLocalTools(resolvedWorkDir, resolvedCommand, func(line string) {
+		o.logToDashboard(0, line)
+	})
 
 	baseEnv := provisioner.BuildEnhancedEnvironment()
 	env := o.buildEnvWithSecrets(baseEnv)
 
-	ctx, cancel := context.WithTimeout(o.dashboard.GetContext(), 30*time.Minute)
+	ctx, cancel := context.WithTimeout(o.dashboard.GetContext(), o.setupTimeout())
 	defer cancel()
 
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.CommandContext(ctx, "cmd", "/C", resolvedCommand)
-	} else {
-		cmd = exec.CommandContext(ctx, "sh", "-c", resolvedCommand)
-	}
+	cmd := o.shellCommand(ctx, resolvedCommand)
 
 	cmd.Dir = resolvedWorkDir
 	cmd.Env = env
@@ -1426,6 +2075,9 @@ func (o *Orchestrator) executeSetupPhaseWithDashboard(workDir string, setupComma
 func (o *Orchestrator) executeWithDashboard(workDir string, runCommand string, isHTMLProject bool) error {
 	resolvedWorkDir, resolvedCommand := o.resolveNestedCommand(workDir, runCommand)
 	resolvedCommand = o.injectConcurrencyFlags(resolvedCommand)
+	resolvedCommand = o.resolveLocalTools(resolvedWorkDir, resolvedCommand, func(line string) {
+		o.logToDashboard(0, line)
+	})
 
 	pmInfo := provisioner.DetectPackageManager(resolvedWorkDir)
 
@@ -1438,50 +2090,95 @@ func (o *Orchestrator) executeWithDashboard(workDir string, runCommand string, i
 
 	env := o.buildEnvWithSecrets(baseEnv)
 
-	ctx := o.dashboard.GetContext()
+	ctx, cancel := o.runContext(o.dashboard.GetContext())
+	defer cancel()
 
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.CommandContext(ctx, "cmd", "/C", resolvedCommand)
-	} else {
-		cmd = exec.CommandContext(ctx, "sh", "-c", resolvedCommand)
-	}
+	newCmd := func(command string) *exec.Cmd {
+		cmd := o.shellCommand(ctx, command)
 
-	cmd.Dir = resolvedWorkDir
-	cmd.Env = env
-	
-	// Set process group so we can kill all child processes together
-	// This is critical for killing dev servers spawned by shell commands
-	if runtime.GOOS != "windows" {
-		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		cmd.Dir = resolvedWorkDir
+		cmd.Env = env
+
+		// Set process group so we can kill all child processes together
+		// This is critical for killing dev servers spawned by shell commands
+		if runtime.GOOS != "windows" {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		}
+		return cmd
 	}
 
+	if isHTMLProject && o.opts.NoBrowser {
+		o.logToDashboard(0, fmt.Sprintf("🌐 Not opening a browser (--no-browser). Open this URL manually: %s", browserURL(resolvedWorkDir, resolvedCommand)))
+		o.logToDashboard(0, fmt.Sprintf("   or run: %s", resolvedCommand))
+		return nil
+	}
 	if isHTMLProject {
-		if err := cmd.Start(); err != nil {
+		if err := newCmd(resolvedCommand).Start(); err != nil {
 			return fmt.Errorf("failed to open browser: %w", err)
 		}
 		o.logToDashboard(0, "🌐 Opened in browser")
 		return nil
 	}
 
-	// Capture output to dashboard
-	stdout, _ := cmd.StdoutPipe()
-	stderr, _ := cmd.StderrPipe()
+	start := func(cmd *exec.Cmd) error {
+		// Capture output to dashboard
+		stdout, _ := cmd.StdoutPipe()
+		stderr, _ := cmd.StderrPipe()
 
-	if err := cmd.Start(); err != nil {
-		return err
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+
+		// Store the command reference in the project for graceful shutdown
+		if project := o.dashboard.GetProject(0); project != nil {
+			project.SetCmd(cmd)
+		}
+		o.recordProcess(cmd, resolvedCommand)
+		o.currentBenchmark().Start()
+
+		// Stream output to dashboard
+		go o.streamToDashboard(0, stdout, "")
+		go o.streamToDashboard(0, stderr, "ERR: ")
+		return nil
 	}
 
-	// Store the command reference in the project for graceful shutdown
-	if project := o.dashboard.GetProject(0); project != nil {
-		project.SetCmd(cmd)
+	// In watch mode, restart the command whenever a watched file changes
+	if o.opts.Watch {
+		return o.runWatched(resolvedWorkDir, func() (*exec.Cmd, error) {
+			cmd := newCmd(resolvedCommand)
+			return cmd, start(cmd)
+		}, o.watchCommandBuilder(func() *exec.Cmd {
+			cmd := newCmd(o.opts.WatchCommand)
+			cmd.Stdout = o.dashboard.GetWriter(0)
+			cmd.Stderr = o.dashboard.GetWriter(0)
+			return cmd
+		}), func(line string) {
+			o.logToDashboard(0, line)
+		})
 	}
 
-	// Stream output to dashboard
-	go o.streamToDashboard(0, stdout, "")
-	go o.streamToDashboard(0, stderr, "ERR: ")
+	// ctrl+r in the dashboard stops the process and launches it again
+	err := o.runRestartable(0, resolvedCommand, func(command string) (func() error, error) {
+		// Each launch is measured from scratch
+		o.currentBenchmark().Stop()
+		o.setBenchmark(o.newStartupBenchmark(command, func(line string) {
+			o.logToDashboard(0, line)
+		}))
 
-	return cmd.Wait()
+		cmd := newCmd(command)
+		if err := start(cmd); err != nil {
+			return nil, err
+		}
+		return cmd.Wait, nil
+	})
+	o.currentBenchmark().Stop()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("run command timed out after %s", o.runTimeout())
+	}
+	if err != nil && o.stopRequested() {
+		return nil
+	}
+	return err
 }
 
 // streamToDashboard streams reader output to the dashboard
@@ -1492,6 +2189,9 @@ func (o *Orchestrator) streamToDashboard(projectIndex int, reader interface{ Rea
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		o.currentBenchmark().ObserveLine(line)
+		o.session.SetLastLog(line)
+		o.observeExitAfter(line)
 		if prefix != "" {
 			line = prefix + line
 		}
@@ -0,0 +1,115 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harshul/octo-cli/internal/provisioner"
+	"github.com/harshul/octo-cli/internal/shellquote"
+)
+
+// ==========================================
+// Print Command (--print-command)
+// ==========================================
+
+// PrintCommand writes the setup and run commands octo would execute, after port shifting,
+// concurrency injection and path resolution, together with the environment it injects.
+// Nothing is installed or started. The output is a shell script that reproduces the run.
+func (o *Orchestrator) PrintCommand() error {
+	w := os.Stdout
+
+	if o.bp.RunCommand == "" {
+		return fmt.Errorf("no run command specified in configuration")
+	}
+
+	workDir := o.opts.WorkDir
+	if workDir == "" {
+		workDir, _ = os.Getwd()
+	}
+	if o.bp.IsMonorepo && o.bp.MonorepoRoot != "" {
+		if info, err := os.Stat(o.bp.MonorepoRoot); err == nil && info.IsDir() {
+			workDir = o.bp.MonorepoRoot
+		}
+	}
+
+	o.loadEnvVarsForInjection(workDir)
+
+	type step struct {
+		label   string
+		workDir string
+		command string
+	}
+	var steps []step
+
+	if o.bp.SetupRequired && o.bp.SetupCommand != "" && !o.opts.SkipSetup {
+		dir, command := o.resolvePrintedCommand(workDir, o.bp.SetupCommand)
+		steps = append(steps, step{"Setup", dir, command})
+	}
+
+	if len(o.services) > 0 {
+		for _, svc := range o.services {
+			dir := workDir
+			if svc.Path != "" {
+				dir = filepath.Join(workDir, svc.Path)
+			}
+			steps = append(steps, step{"Service " + svc.Name, dir, o.injectConcurrencyFlags(svc.RunCommand)})
+		}
+	} else {
+		runCommand := o.bp.RunCommand
+		if strings.ToLower(o.bp.Language) != "html" {
+			runCommand = o.applyPortHandling(runCommand)
+		}
+		dir, command := o.resolvePrintedCommand(workDir, runCommand)
+		steps = append(steps, step{"Run", dir, command})
+	}
+
+	// Only the variables octo adds or changes; the rest is inherited from this shell
+	baseEnv := provisioner.BuildEnhancedEnvironment()
+	if o.usesTurbo(steps[len(steps)-1].command) {
+		pmInfo := provisioner.DetectPackageManager(steps[len(steps)-1].workDir)
+		baseEnv = provisioner.BuildEnhancedEnvironmentWithTurbo(pmInfo.Manager, pmInfo.Version)
+	}
+	injected := injectedEnv(o.buildEnvWithSecrets(baseEnv))
+
+	fmt.Fprintf(w, "# Environment added by octo (%d variable(s))\n", len(injected))
+	for _, kv := range injected {
+		name, value, _ := strings.Cut(kv, "=")
+		fmt.Fprintf(w, "export %s=%s\n", name, shellquote.Quote(value))
+	}
+	shell, flag := o.shellInvocation()
+	for _, s := range steps {
+		fmt.Fprintf(w, "\n# %s (%s %s)\n", s.label, shell, flag)
+		fmt.Fprintf(w, "cd %s && %s\n", shellquote.Quote(s.workDir), s.command)
+	}
+	return nil
+}
+
+// resolvePrintedCommand applies the same rewrites to a command as the setup and run phases do
+func (o *Orchestrator) resolvePrintedCommand(workDir string, command string) (string, string) {
+	resolvedWorkDir, resolvedCommand := o.resolveNestedCommand(workDir, command)
+	resolvedCommand = o.injectConcurrencyFlags(resolvedCommand)
+	resolvedCommand = o.resolveLocalTools(resolvedWorkDir, resolvedCommand, func(string) {})
+	return resolvedWorkDir, resolvedCommand
+}
+
+// injectedEnv returns the KEY=value entries of env that differ from the current environment, sorted
+func injectedEnv(env []string) []string {
+	current := make(map[string]string)
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		current[name] = value
+	}
+
+	var injected []string
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if existing, ok := current[name]; !ok || existing != value {
+			injected = append(injected, kv)
+		}
+	}
+	sort.Strings(injected)
+	return injected
+}
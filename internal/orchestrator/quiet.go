@@ -0,0 +1,28 @@
+package orchestrator
+
+import (
+	"io"
+	"os"
+)
+
+// ==========================================
+// Quiet Mode (--quiet)
+// ==========================================
+
+// statusOutput returns where the orchestrator writes its own status lines: nowhere with --quiet,
+// stdout otherwise. The app keeps writing to stdout either way.
+func statusOutput(quiet bool) io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+// commandOutput returns where setup and install commands write. With --quiet they go to stderr,
+// so stdout carries only the app's output while failures stay visible.
+func (o *Orchestrator) commandOutput() io.Writer {
+	if o.opts.Quiet {
+		return os.Stderr
+	}
+	return os.Stdout
+}
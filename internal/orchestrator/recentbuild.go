@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ==========================================
+// Skipping Fresh Builds (--skip-build-if-recent)
+// ==========================================
+
+// buildSourceExtensions are the files whose changes make a local binary stale
+var buildSourceExtensions = map[string]bool{
+	".go": true, ".mod": true, ".sum": true,
+	".ts": true, ".tsx": true, ".js": true, ".jsx": true, ".mjs": true, ".cjs": true,
+	".rs": true, ".c": true, ".cc": true, ".cpp": true, ".h": true, ".hpp": true,
+	".java": true, ".kt": true, ".swift": true, ".zig": true,
+	".mk": true,
+}
+
+// buildSkipDirs are dependency and output directories that never hold the binary's sources
+var buildSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true, "bin": true,
+}
+
+// binaryIsRecent reports whether the binary at binaryPath was built within window of now and
+// no source file under workDir has changed since, so rebuilding it can be skipped.
+func binaryIsRecent(workDir string, binaryPath string, window time.Duration) bool {
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		return false
+	}
+	if time.Since(info.ModTime()) > window {
+		return false
+	}
+
+	// Any source edited after the build makes the binary stale, however recent it is
+	newest, ok := newestSourceModTime(workDir)
+	return ok && !newest.After(info.ModTime())
+}
+
+// newestSourceModTime returns the latest modification time of the source files under root
+func newestSourceModTime(root string) (time.Time, bool) {
+	var newest time.Time
+	found := false
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (buildSkipDirs[name] || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !buildSourceExtensions[filepath.Ext(name)] && name != "Makefile" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		found = true
+		return nil
+	})
+
+	return newest, found
+}
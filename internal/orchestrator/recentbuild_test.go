@@ -0,0 +1,41 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBinaryIsRecent(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		binaryAge time.Duration
+		sourceAge time.Duration
+		want      bool
+	}{
+		{"built after the last edit", time.Minute, 2 * time.Minute, true},
+		{"source edited after the build", 2 * time.Minute, time.Minute, false},
+		{"source edited seconds after the build", 2 * time.Minute, 2*time.Minute - time.Second, false},
+		{"binary older than the window", time.Hour, 2 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		binary := filepath.Join(dir, "app")
+		source := filepath.Join(dir, "main.go")
+		for path, age := range map[string]time.Duration{binary: tt.binaryAge, source: tt.sourceAge} {
+			if err := os.WriteFile(path, nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got := binaryIsRecent(dir, binary, 5*time.Minute); got != tt.want {
+			t.Errorf("%s: binaryIsRecent = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/harshul/octo-cli/internal/ui"
+)
+
+// ==========================================
+// Dashboard Restarts (ctrl+r)
+// ==========================================
+
+// runRestartable launches command for the dashboard project at index and waits for it to exit.
+// When the process was stopped by a restart from the dashboard, its logs are cleared and the
+// project's RunCmd is launched again. launch starts a process and returns the function that
+// waits for it.
+func (o *Orchestrator) runRestartable(index int, command string, launch func(command string) (wait func() error, err error)) error {
+	project := o.dashboard.GetProject(index)
+	if project != nil {
+		project.SetRunCmd(command)
+	}
+
+	wait, err := launch(command)
+	if err != nil {
+		return err
+	}
+
+	for {
+		err := wait()
+		if project == nil || !project.TakeRestartRequest() || o.stopRequested() || o.dashboard.GetContext().Err() != nil {
+			return err
+		}
+
+		project.ClearLogs()
+		o.dashboard.UpdateProject(index, ui.PhaseRun, ui.StatusPending)
+
+		command := project.GetRunCmd()
+		o.logToDashboard(index, fmt.Sprintf("🔄 Restarting: %s", command))
+		if wait, err = launch(command); err != nil {
+			return err
+		}
+		o.dashboard.UpdateProject(index, ui.PhaseRun, ui.StatusRunning)
+	}
+}
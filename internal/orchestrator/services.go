@@ -0,0 +1,170 @@
+package orchestrator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/provisioner"
+	"github.com/harshul/octo-cli/internal/ui"
+)
+
+// ==========================================
+// Selective Service Execution (--services)
+// ==========================================
+
+// runServices starts each selected service concurrently and waits for all of them to exit.
+// The first service failure is returned once every service has stopped.
+func (o *Orchestrator) runServices(workDir string, services []blueprint.Service) error {
+	// Announced here rather than in New so --quiet, --print-command and --export-pid keep stdout clean
+	if o.procfile {
+		var names []string
+		for _, svc := range services {
+			names = append(names, svc.Name)
+		}
+		line := fmt.Sprintf("📄 No run command configured, using Procfile (%s)", strings.Join(names, ", "))
+		if o.dashboard != nil {
+			o.logToDashboard(0, line)
+		} else {
+			fmt.Fprintln(o.out, line)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(services))
+
+	for i, svc := range services {
+		wg.Add(1)
+		go func(index int, svc blueprint.Service) {
+			defer wg.Done()
+			if err := o.runService(index, workDir, svc); err != nil {
+				errs <- fmt.Errorf("service %s failed: %w", svc.Name, err)
+			}
+		}(i, svc)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if o.stopRequested() {
+		return nil
+	}
+	return <-errs
+}
+
+// runService executes a single service's run command inside its own directory
+func (o *Orchestrator) runService(index int, workDir string, svc blueprint.Service) error {
+	if svc.RunCommand == "" {
+		return fmt.Errorf("no run command specified for service")
+	}
+
+	serviceDir := workDir
+	if svc.Path != "" {
+		serviceDir = filepath.Join(workDir, svc.Path)
+	}
+
+	runCommand := o.injectConcurrencyFlags(svc.RunCommand)
+	env := o.buildEnvWithSecrets(provisioner.BuildEnhancedEnvironment())
+
+	ctx := context.Background()
+	if o.dashboard != nil {
+		ctx = o.dashboard.GetContext()
+	}
+
+	launch := func(command string) (func() error, error) {
+		cmd := o.shellCommand(ctx, command)
+
+		cmd.Dir = serviceDir
+		cmd.Env = env
+
+		// Set process group so we can kill all child processes together
+		if runtime.GOOS != "windows" {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		}
+
+		stdout, _ := cmd.StdoutPipe()
+		stderr, _ := cmd.StderrPipe()
+
+		o.serviceLog(index, svc.Name, fmt.Sprintf("📦 Executing: %s (in %s)", command, serviceDir))
+
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		o.trackProcess(cmd)
+
+		if o.dashboard != nil {
+			if project := o.dashboard.GetProject(index); project != nil {
+				project.SetCmd(cmd)
+			}
+			o.dashboard.UpdateProject(index, ui.PhaseRun, ui.StatusRunning)
+		}
+
+		var streams sync.WaitGroup
+		streams.Add(2)
+		go func() {
+			defer streams.Done()
+			o.streamService(index, svc.Name, stdout, "")
+		}()
+		go func() {
+			defer streams.Done()
+			o.streamService(index, svc.Name, stderr, "ERR: ")
+		}()
+
+		return func() error {
+			streams.Wait()
+			defer o.untrackProcess(cmd)
+			return cmd.Wait()
+		}, nil
+	}
+
+	var err error
+	if o.dashboard != nil {
+		// ctrl+r in the dashboard restarts the service
+		err = o.runRestartable(index, runCommand, launch)
+	} else {
+		var wait func() error
+		if wait, err = launch(runCommand); err != nil {
+			return err
+		}
+		err = wait()
+	}
+
+	if o.dashboard != nil {
+		if err != nil {
+			o.dashboard.UpdateProject(index, ui.PhaseRun, ui.StatusError)
+		} else {
+			o.dashboard.UpdateProject(index, ui.PhaseRun, ui.StatusSuccess)
+		}
+	}
+	return err
+}
+
+// streamService forwards a service's output line by line, prefixed with the service name
+func (o *Orchestrator) streamService(index int, name string, reader io.Reader, prefix string) {
+	scanner := bufio.NewScanner(reader)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	for scanner.Scan() {
+		o.session.SetLastLog(fmt.Sprintf("[%s] %s", name, scanner.Text()))
+		o.observeExitAfter(scanner.Text())
+		o.serviceLog(index, name, prefix+scanner.Text())
+	}
+}
+
+// serviceLog writes a line to the service's dashboard panel, or to stdout with a [name] prefix
+func (o *Orchestrator) serviceLog(index int, name string, line string) {
+	if o.dashboard != nil {
+		o.logToDashboard(index, line)
+		return
+	}
+	fmt.Fprintf(os.Stdout, "[%s] %s\n", name, line)
+}
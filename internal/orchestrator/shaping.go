@@ -0,0 +1,87 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ==========================================
+// Traffic Shaping (--rate-limit, --latency)
+// ==========================================
+
+// trafficShaper slows down requests through --forward-port to simulate slow networks.
+// With shaping enabled the forwarder is an HTTP reverse proxy, so every request is
+// limited, including requests sent over one keep-alive connection.
+type trafficShaper struct {
+	limiter *rate.Limiter // nil means no rate limit
+	latency time.Duration // Delay added before each request is forwarded
+}
+
+// newTrafficShaper returns nil when neither --rate-limit nor --latency is set
+func (o *Orchestrator) newTrafficShaper() *trafficShaper {
+	if o.opts.RateLimit <= 0 && o.opts.Latency <= 0 {
+		return nil
+	}
+	shaper := &trafficShaper{latency: o.opts.Latency}
+	if o.opts.RateLimit > 0 {
+		// Bursts of up to one second's worth of requests, and at least one
+		burst := int(math.Ceil(o.opts.RateLimit))
+		shaper.limiter = rate.NewLimiter(rate.Limit(o.opts.RateLimit), burst)
+	}
+	return shaper
+}
+
+// Wait holds a request back until the rate limit lets it through, then adds the latency.
+// It gives up with ctx's error when the client goes away. A nil shaper returns immediately.
+func (s *trafficShaper) Wait(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if s.latency > 0 {
+		select {
+		case <-time.After(s.latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Handler returns a reverse proxy to target that passes every request through the shaper
+func (s *trafficShaper) Handler(target *url.URL) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	// The app may not be listening yet; answer 502 without logging over the app's output
+	proxy.ErrorLog = log.New(io.Discard, "", 0)
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := s.Wait(r.Context()); err != nil {
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// describeRateLimit formats a --rate-limit value for the startup message
+func describeRateLimit(limit float64) string {
+	if limit <= 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%g req/s", limit)
+}
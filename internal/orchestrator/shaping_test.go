@@ -0,0 +1,187 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrafficShaperWait(t *testing.T) {
+	var nilShaper *trafficShaper
+	begin := time.Now()
+	if err := nilShaper.Wait(context.Background()); err != nil {
+		t.Errorf("expected a nil shaper to admit, got %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > 20*time.Millisecond {
+		t.Errorf("expected a nil shaper to admit immediately, took %s", elapsed)
+	}
+
+	o := &Orchestrator{opts: Options{Latency: 30 * time.Millisecond}}
+	if o.newTrafficShaper() == nil {
+		t.Fatal("expected a shaper when --latency is set")
+	}
+	if (&Orchestrator{}).newTrafficShaper() != nil {
+		t.Error("expected no shaper without --rate-limit or --latency")
+	}
+
+	begin = time.Now()
+	if err := o.newTrafficShaper().Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed < 30*time.Millisecond {
+		t.Errorf("expected Wait to add the latency, took %s", elapsed)
+	}
+
+	// A client that goes away stops waiting
+	slow := &trafficShaper{latency: time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := slow.Wait(ctx); err == nil {
+		t.Error("expected Wait to return the context's error")
+	}
+}
+
+func TestTrafficShaperRateLimitBurst(t *testing.T) {
+	// Fractional rates still allow one request up front
+	o := &Orchestrator{opts: Options{RateLimit: 0.5}}
+	if burst := o.newTrafficShaper().limiter.Burst(); burst != 1 {
+		t.Errorf("expected a burst of 1 at 0.5 req/s, got %d", burst)
+	}
+
+	o = &Orchestrator{opts: Options{RateLimit: 4}}
+	if burst := o.newTrafficShaper().limiter.Burst(); burst != 4 {
+		t.Errorf("expected a burst of one second's worth (4), got %d", burst)
+	}
+}
+
+// newShapedProxy starts an app that counts its requests and a shaping proxy in front of it
+func newShapedProxy(t *testing.T, shaper *trafficShaper) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(app.Close)
+
+	target, err := url.Parse(app.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(shaper.Handler(target))
+	t.Cleanup(proxy.Close)
+	return proxy, &requests
+}
+
+// getAll sends n requests over one keep-alive connection and returns how long they took
+func getAll(t *testing.T, proxy *httptest.Server, n int) time.Duration {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{MaxConnsPerHost: 1}}
+	defer client.CloseIdleConnections()
+
+	begin := time.Now()
+	for i := 0; i < n; i++ {
+		resp, err := client.Get(proxy.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "ok" {
+			t.Fatalf("request %d: expected the app's response, got %q", i, body)
+		}
+	}
+	return time.Since(begin)
+}
+
+func TestShapedProxyLatencyPerRequest(t *testing.T) {
+	proxy, requests := newShapedProxy(t, &trafficShaper{latency: 30 * time.Millisecond})
+
+	// Keep-alive requests share a connection but each one pays the latency
+	if elapsed := getAll(t, proxy, 3); elapsed < 90*time.Millisecond {
+		t.Errorf("expected 3 requests to take at least 90ms, took %s", elapsed)
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("expected 3 requests to reach the app, got %d", got)
+	}
+}
+
+func TestShapedProxyRateLimitPerRequest(t *testing.T) {
+	o := &Orchestrator{opts: Options{RateLimit: 10}}
+	proxy, _ := newShapedProxy(t, o.newTrafficShaper())
+
+	// The first 10 requests are the burst; the next 3 wait 100ms each
+	if elapsed := getAll(t, proxy, 13); elapsed < 250*time.Millisecond {
+		t.Errorf("expected requests beyond the burst to be limited, 13 took %s", elapsed)
+	}
+}
+
+func TestShapedProxyAppDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	proxy := httptest.NewServer((&trafficShaper{}).Handler(&url.URL{Scheme: "http", Host: addr}))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502 while the app is not listening, got %d", resp.StatusCode)
+	}
+}
+
+func TestProxyConnForwardsUnchanged(t *testing.T) {
+	// Echo server standing in for the app
+	app, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+	go func() {
+		for {
+			conn, err := app.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	client, server := net.Pipe()
+	defer client.Close()
+	tracker := &connTracker{conns: make(map[net.Conn]struct{})}
+	target := net.JoinHostPort("127.0.0.1", strconv.Itoa(app.Addr().(*net.TCPAddr).Port))
+	go proxyConn(server, target, tracker)
+
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+	go func() {
+		for i := 0; i < len(payload); i += 1024 {
+			client.Write(payload[i : i+1024])
+		}
+	}()
+	got := make([]byte, len(payload))
+	if _, err := io.ReadFull(client, got); err != nil {
+		t.Fatalf("reading the echoed payload: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Error("expected the payload to be forwarded unchanged")
+	}
+}
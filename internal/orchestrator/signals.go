@@ -0,0 +1,136 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/ports"
+)
+
+// ==========================================
+// Signal Forwarding
+// ==========================================
+
+// GracefulTimeout is how long child processes get to exit after a forwarded signal
+// before they are killed with SIGKILL
+const GracefulTimeout = 5 * time.Second
+
+// trackProcess registers a started command so Stop can forward signals to it
+func (o *Orchestrator) trackProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+
+	o.procMu.Lock()
+	defer o.procMu.Unlock()
+
+	if o.running == nil {
+		o.running = make(map[*exec.Cmd]chan struct{})
+	}
+	o.running[cmd] = make(chan struct{})
+}
+
+// untrackProcess marks a command as exited; call it once Wait has returned
+func (o *Orchestrator) untrackProcess(cmd *exec.Cmd) {
+	o.procMu.Lock()
+	defer o.procMu.Unlock()
+
+	if done, ok := o.running[cmd]; ok {
+		close(done)
+		delete(o.running, cmd)
+	}
+}
+
+// runTracked runs cmd to completion while Stop can forward signals to it, so Ctrl+C during
+// installs, builds and setup reaches them instead of leaving octo waiting
+func (o *Orchestrator) runTracked(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	o.trackProcess(cmd)
+	err := cmd.Wait()
+	o.untrackProcess(cmd)
+	return err
+}
+
+// stopRequested reports whether Stop has been called, so exits caused by it are not treated as failures
+func (o *Orchestrator) stopRequested() bool {
+	o.procMu.Lock()
+	defer o.procMu.Unlock()
+	return o.stopping
+}
+
+// errStopped is returned by Run when Stop interrupted setup, installs or --wait-for
+var errStopped = errors.New("stopped by signal")
+
+// Stop forwards sig to every running child process and waits up to GracefulTimeout
+// for them to exit, killing any that remain. It also ends --wait-for and pending prompts,
+// after which Run skips the remaining steps and returns, running its cleanup.
+// It returns false if nothing was running.
+func (o *Orchestrator) Stop(sig os.Signal) bool {
+	return o.stopRunning(sig, fmt.Sprintf("\n🛑 Received %s, stopping (force kill in %s)...", sig, GracefulTimeout))
+}
+
+// stopRunning implements Stop, printing message before the processes are signalled
+func (o *Orchestrator) stopRunning(sig os.Signal, message string) bool {
+	o.procMu.Lock()
+	o.stopping = true
+	o.cancelStop()
+	running := make(map[*exec.Cmd]chan struct{}, len(o.running))
+	for cmd, done := range o.running {
+		running[cmd] = done
+	}
+	o.procMu.Unlock()
+
+	if len(running) == 0 {
+		return false
+	}
+
+	fmt.Fprintln(o.out, message)
+	for cmd := range running {
+		signalProcess(cmd, sig)
+	}
+
+	timer := time.NewTimer(GracefulTimeout)
+	defer timer.Stop()
+
+	expired := false
+	for cmd, done := range running {
+		if !expired {
+			select {
+			case <-done:
+				continue
+			case <-timer.C:
+				expired = true
+			}
+		}
+		select {
+		case <-done:
+		default:
+			fmt.Fprintf(o.out, "⚠️  Process %d did not exit in time, killing it\n", cmd.Process.Pid)
+			signalProcess(cmd, syscall.SIGKILL)
+			<-done
+		}
+	}
+	return true
+}
+
+// signalProcess sends sig to the command and everything it started.
+// Commands with their own process group are signalled as a group; otherwise each
+// descendant is signalled, since shells don't forward signals to the commands they run.
+func signalProcess(cmd *exec.Cmd, sig os.Signal) {
+	if s, ok := sig.(syscall.Signal); ok {
+		if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+			syscall.Kill(-cmd.Process.Pid, s)
+			return
+		}
+		for _, pid := range ports.DescendantPIDs(cmd.Process.Pid) {
+			syscall.Kill(pid, s)
+		}
+	}
+	cmd.Process.Signal(sig)
+}
@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+)
+
+func newTestOrchestrator(t *testing.T, opts Options) *Orchestrator {
+	t.Helper()
+	opts.WorkDir = t.TempDir()
+	opts.Quiet = true
+	o, err := New(blueprint.Blueprint{Name: "demo"}, opts)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return o
+}
+
+func TestStopInterruptsWaitFor(t *testing.T) {
+	o := newTestOrchestrator(t, Options{WaitFor: []string{"127.0.0.1:1"}, WaitTimeout: time.Minute})
+
+	done := make(chan error, 1)
+	go func() { done <- o.waitForDependencies(func(string) {}) }()
+	time.Sleep(50 * time.Millisecond)
+
+	if o.Stop(os.Interrupt) {
+		t.Error("expected Stop to report that no process was running")
+	}
+	select {
+	case err := <-done:
+		if err != errStopped {
+			t.Errorf("waitForDependencies = %v, want errStopped", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected Stop to end --wait-for")
+	}
+}
+
+func TestStopSignalsTrackedSetupCommands(t *testing.T) {
+	o := newTestOrchestrator(t, Options{})
+
+	done := make(chan error, 1)
+	go func() { done <- o.runTracked(exec.Command("sleep", "30")) }()
+
+	// Wait for the command to be registered
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		o.procMu.Lock()
+		n := len(o.running)
+		o.procMu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected runTracked to register the command")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !o.Stop(syscall.SIGTERM) {
+		t.Error("expected Stop to report a running process")
+	}
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected the interrupted command to fail")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected Stop to end the setup command")
+	}
+	if !o.stopRequested() {
+		t.Error("expected stopRequested after Stop")
+	}
+}
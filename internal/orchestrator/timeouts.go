@@ -0,0 +1,36 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+)
+
+// ==========================================
+// Phase Timeouts
+// ==========================================
+
+// defaultSetupTimeout is used when the blueprint sets no setup_timeout_minutes.
+// It is generous so first installs in large monorepos can finish.
+const defaultSetupTimeout = 30 * time.Minute
+
+// setupTimeout returns how long the setup command may run
+func (o *Orchestrator) setupTimeout() time.Duration {
+	if o.bp.SetupTimeoutMinutes > 0 {
+		return time.Duration(o.bp.SetupTimeoutMinutes) * time.Minute
+	}
+	return defaultSetupTimeout
+}
+
+// runContext derives the context of the run command from parent.
+// The run command only has a deadline when the blueprint sets run_timeout_minutes.
+func (o *Orchestrator) runContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if o.bp.RunTimeoutMinutes > 0 {
+		return context.WithTimeout(parent, o.runTimeout())
+	}
+	return context.WithCancel(parent)
+}
+
+// runTimeout returns the configured run timeout, or 0 if the run command may run indefinitely
+func (o *Orchestrator) runTimeout() time.Duration {
+	return time.Duration(o.bp.RunTimeoutMinutes) * time.Minute
+}
@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// ==========================================
+// Dependency Waiting (--wait-for)
+// ==========================================
+
+// DefaultWaitTimeout is how long --wait-for polls before giving up
+const DefaultWaitTimeout = 60 * time.Second
+
+// waitForInterval is the delay between connection attempts
+const waitForInterval = time.Second
+
+// waitForDependencies blocks until every --wait-for address accepts TCP connections.
+// It returns an error naming the first address that is still unreachable after --wait-timeout.
+// logf is used for progress so the same code serves plain and dashboard mode.
+func (o *Orchestrator) waitForDependencies(logf func(string)) error {
+	if len(o.opts.WaitFor) == 0 {
+		return nil
+	}
+
+	timeout := o.opts.WaitTimeout
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for _, addr := range o.opts.WaitFor {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("invalid --wait-for address %q (expected host:port): %w", addr, err)
+		}
+
+		logf(fmt.Sprintf("⏳ Waiting for %s...", addr))
+		start := time.Now()
+		for {
+			conn, err := net.DialTimeout("tcp", addr, waitForInterval)
+			if err == nil {
+				conn.Close()
+				logf(fmt.Sprintf("✅ %s is available (after %s)", addr, time.Since(start).Round(time.Second)))
+				break
+			}
+
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s waiting for %s", timeout, addr)
+			}
+
+			logf(fmt.Sprintf("   %s not ready yet (%s elapsed)", addr, time.Since(start).Round(time.Second)))
+			select {
+			case <-o.stopCtx.Done():
+				return errStopped
+			case <-time.After(waitForInterval):
+			}
+		}
+	}
+
+	return nil
+}
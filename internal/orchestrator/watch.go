@@ -0,0 +1,138 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/harshul/octo-cli/internal/blueprint"
+	"github.com/harshul/octo-cli/internal/watcher"
+)
+
+// ==========================================
+// File Watching (--watch)
+// ==========================================
+
+// runWatched starts the process via start and restarts it whenever a watched file changes.
+// The blueprint's WatchPaths and WatchIgnorePaths control what is observed.
+// If newWatchCmd is set, the command it builds (--watch-command) runs after each change,
+// before the restart. logf is used for status output so the same loop serves plain and dashboard mode.
+func (o *Orchestrator) runWatched(workDir string, start func() (*exec.Cmd, error), newWatchCmd func() *exec.Cmd, logf func(string)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if o.dashboard != nil {
+		go func() {
+			select {
+			case <-o.dashboard.GetContext().Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	// Configurations written before watch_ignore_paths existed leave it empty; without the
+	// language defaults, node_modules or target would be watched as well
+	ignore := o.bp.WatchIgnorePaths
+	if len(ignore) == 0 {
+		ignore = blueprint.DefaultWatchIgnorePaths(o.bp.Language)
+	}
+	w := watcher.New(workDir, o.bp.WatchPaths, ignore)
+	changes := w.Watch(ctx)
+	logf(fmt.Sprintf("👀 Watching %s for changes...", workDir))
+
+	// Stop the process group on Ctrl+C since the child runs in its own group
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	for {
+		cmd, err := start()
+		if err != nil {
+			return err
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				logf(fmt.Sprintf("❌ Process exited: %v", err))
+			} else {
+				logf("✅ Process exited")
+			}
+			logf("👀 Waiting for changes before restarting...")
+			select {
+			case path, ok := <-changes:
+				if !ok {
+					return err
+				}
+				logf(fmt.Sprintf("🔄 %s changed, restarting...", path))
+				o.runWatchCommand(newWatchCmd, logf)
+			case <-sigChan:
+				return nil
+			}
+
+		case path, ok := <-changes:
+			stopProcessGroup(cmd, done)
+			if !ok {
+				return nil
+			}
+			logf(fmt.Sprintf("🔄 %s changed, restarting...", path))
+			o.runWatchCommand(newWatchCmd, logf)
+
+		case <-sigChan:
+			stopProcessGroup(cmd, done)
+			return nil
+		}
+	}
+}
+
+// watchCommandBuilder returns newWatchCmd if --watch-command was given, or nil otherwise
+func (o *Orchestrator) watchCommandBuilder(newWatchCmd func() *exec.Cmd) func() *exec.Cmd {
+	if o.opts.WatchCommand == "" {
+		return nil
+	}
+	return newWatchCmd
+}
+
+// runWatchCommand runs the --watch-command between stopping and restarting the process.
+// A failing command is reported but does not prevent the restart.
+func (o *Orchestrator) runWatchCommand(newWatchCmd func() *exec.Cmd, logf func(string)) {
+	if newWatchCmd == nil {
+		return
+	}
+
+	logf(fmt.Sprintf("🧪 Running watch command: %s", o.opts.WatchCommand))
+	if err := newWatchCmd().Run(); err != nil {
+		logf(fmt.Sprintf("❌ Watch command failed: %v", err))
+		return
+	}
+	logf("✅ Watch command passed")
+}
+
+// stopProcessGroup terminates a command started with Setpgid and waits for it to exit.
+// It sends SIGTERM first and escalates to SIGKILL if the process does not exit in time.
+func stopProcessGroup(cmd *exec.Cmd, done <-chan error) {
+	if cmd.Process == nil {
+		return
+	}
+
+	pid := cmd.Process.Pid
+	syscall.Kill(-pid, syscall.SIGTERM)
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		syscall.Kill(-pid, syscall.SIGKILL)
+		cmd.Process.Kill()
+		<-done
+	}
+}
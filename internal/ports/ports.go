@@ -258,6 +258,11 @@ func ShiftPort(runCommand string, oldPort, newPort int) string {
 		if !strings.Contains(runCommand, "-Dserver.port") {
 			result = runCommand + " -Dserver.port=" + newPortStr
 		}
+	} else if strings.Contains(runCommand, "cargo run") || strings.Contains(runCommand, "go run") {
+		// Rust and Go servers read the port from the PORT environment variable
+		if !strings.HasPrefix(runCommand, "PORT=") {
+			result = "PORT=" + newPortStr + " " + runCommand
+		}
 	} else if strings.Contains(runCommand, "java") {
 		// Generic Java: append -Dserver.port before -jar or at the end
 		if !strings.Contains(runCommand, "-Dserver.port") {
@@ -339,8 +344,13 @@ func AppendPortFlag(runCommand string, language string, port int) string {
 	case "ruby":
 		return runCommand + " -p " + portStr
 		
-	case "go", "golang":
-		return runCommand + " --port " + portStr
+	case "rust", "go", "golang":
+		// Rust (Actix-Web, Axum) and Go servers conventionally read PORT from the
+		// environment; a trailing --port would be passed to the program as an argument
+		if !strings.HasPrefix(runCommand, "PORT=") {
+			return "PORT=" + portStr + " " + runCommand
+		}
+		return runCommand
 		
 	default:
 		return runCommand + " --port " + portStr
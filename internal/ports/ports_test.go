@@ -1,7 +1,11 @@
 package ports
 
 import (
+	"fmt"
+	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -25,3 +29,78 @@ func TestFindAvailablePort(t *testing.T) {
 		t.Errorf("FindAvailablePort(%d) = %d; want %d (because %d is busy)", blockedPort, got, blockedPort+1, blockedPort)
 	}
 }
+
+func TestAppendPortFlagEnvPrefix(t *testing.T) {
+	tests := []struct {
+		name       string
+		runCommand string
+		language   string
+		want       string
+	}{
+		{"rust cargo run", "cargo run", "Rust", "PORT=8081 cargo run"},
+		{"rust with RUST_LOG", "RUST_LOG=info cargo run", "Rust", "PORT=8081 RUST_LOG=info cargo run"},
+		{"go run", "go run .", "Go", "PORT=8081 go run ."},
+		{"golang alias", "go run main.go", "golang", "PORT=8081 go run main.go"},
+		{"existing PORT prefix kept", "PORT=3000 cargo run", "Rust", "PORT=3000 cargo run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AppendPortFlag(tt.runCommand, tt.language, 8081)
+			if got != tt.want {
+				t.Errorf("AppendPortFlag(%q, %q, 8081) = %q; want %q", tt.runCommand, tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLsofPort(t *testing.T) {
+	output := "p1234\nf22\nn*:5173\nf23\nn[::1]:3000\nn127.0.0.1:8080\n"
+	if got := parseLsofPort(output); got != 3000 {
+		t.Errorf("expected lowest port 3000, got %d", got)
+	}
+	if got := parseLsofPort(""); got != 0 {
+		t.Errorf("expected 0 for empty output, got %d", got)
+	}
+}
+
+func TestListOctoManagedPorts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	session, err := RegisterOctoProcess("demo", "/tmp/demo")
+	if err != nil {
+		t.Fatalf("RegisterOctoProcess failed: %v", err)
+	}
+
+	// An entry for a process that no longer exists should be cleaned up
+	dir, _ := PIDDir()
+	stale := filepath.Join(dir, "999999999"+pidFileSuffix)
+	if err := os.WriteFile(stale, []byte(`{"project":"gone","pid":999999999}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Output lines are flushed to the entry periodically; write directly instead of waiting
+	fmt.Fprint(session.Output(io.Discard), "starting\nlistening on :3000\n")
+	if err := session.write(); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := ListOctoManagedPorts()
+	if err != nil {
+		t.Fatalf("ListOctoManagedPorts failed: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Project != "demo" || infos[0].PID != os.Getpid() {
+		t.Errorf("expected only the current process, got %+v", infos)
+	}
+	if len(infos) == 1 && infos[0].LastLog != "listening on :3000" {
+		t.Errorf("expected last log line to be recorded, got %q", infos[0].LastLog)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expected stale entry to be removed")
+	}
+
+	session.Close()
+	if infos, _ := ListOctoManagedPorts(); len(infos) != 0 {
+		t.Errorf("expected no sessions after Close, got %+v", infos)
+	}
+}
@@ -0,0 +1,297 @@
+package ports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// pidFileSuffix identifies registry entries in ~/.octo/pids
+const pidFileSuffix = ".octo.pid"
+
+// lastLogFlushInterval limits how often a session rewrites its registry entry with new output
+const lastLogFlushInterval = time.Second
+
+// OctoPortInfo describes a running `octo run` session and the port its app listens on
+type OctoPortInfo struct {
+	Project   string    `json:"project"`
+	PID       int       `json:"pid"` // PID of the octo process itself
+	WorkDir   string    `json:"work_dir"`
+	StartTime time.Time `json:"start_time"`
+	LastLog   string    `json:"last_log,omitempty"` // Most recent output line, updated about once a second
+	Port      int       `json:"-"`                  // Resolved at list time, 0 if nothing is listening yet
+}
+
+// OctoSession is the registry entry of the current octo process
+type OctoSession struct {
+	path string
+
+	mu     sync.Mutex
+	info   OctoPortInfo
+	dirty  bool
+	closed bool
+	stop   chan struct{}
+}
+
+// PIDDir returns the directory octo sessions register themselves in (~/.octo/pids)
+func PIDDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".octo", "pids"), nil
+}
+
+// RegisterOctoProcess records the current octo process so `octo ps` can find it.
+// Call Close on the returned session when the run ends to remove the entry.
+func RegisterOctoProcess(project string, workDir string) (*OctoSession, error) {
+	dir, err := PIDDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	session := &OctoSession{
+		info: OctoPortInfo{
+			Project:   project,
+			PID:       os.Getpid(),
+			WorkDir:   workDir,
+			StartTime: time.Now(),
+		},
+		stop: make(chan struct{}),
+	}
+	session.path = filepath.Join(dir, strconv.Itoa(session.info.PID)+pidFileSuffix)
+
+	if err := session.write(); err != nil {
+		return nil, err
+	}
+	go session.flushLoop()
+	return session, nil
+}
+
+// SetLastLog records the most recent output line of the running app
+func (s *OctoSession) SetLastLog(line string) {
+	if s == nil || strings.TrimSpace(line) == "" {
+		return
+	}
+
+	s.mu.Lock()
+	s.info.LastLog = strings.TrimSpace(line)
+	s.dirty = true
+	s.mu.Unlock()
+}
+
+// Output wraps w so everything written through it updates the session's last log line
+func (s *OctoSession) Output(w io.Writer) io.Writer {
+	if s == nil {
+		return w
+	}
+	return sessionWriter{s: s, w: w}
+}
+
+// Close stops updating the entry and removes it from the registry
+func (s *OctoSession) Close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.stop)
+	os.Remove(s.path)
+}
+
+// flushLoop periodically writes the latest output line to the registry entry
+func (s *OctoSession) flushLoop() {
+	ticker := time.NewTicker(lastLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			dirty := s.dirty
+			s.dirty = false
+			s.mu.Unlock()
+			if dirty {
+				s.write()
+			}
+		}
+	}
+}
+
+// write saves the session to its registry file
+func (s *OctoSession) write() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Never recreate the entry once Close has removed it
+	if s.closed {
+		return nil
+	}
+
+	data, err := json.Marshal(s.info)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so `octo ps` never reads a partial entry
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// ListOctoManagedPorts returns every registered octo session that is still alive,
+// together with the port its app is listening on. Stale entries are removed.
+func ListOctoManagedPorts() ([]OctoPortInfo, error) {
+	dir, err := PIDDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var infos []OctoPortInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), pidFileSuffix) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var info OctoPortInfo
+		if err := json.Unmarshal(data, &info); err != nil || info.PID <= 0 {
+			continue
+		}
+
+		if !IsProcessAlive(info.PID) {
+			os.Remove(path)
+			continue
+		}
+
+		info.Port = listeningPort(DescendantPIDs(info.PID))
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].StartTime.Before(infos[j].StartTime)
+	})
+	return infos, nil
+}
+
+// IsProcessAlive reports whether a process with the given PID exists
+func IsProcessAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 performs the existence and permission checks without sending anything
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
+
+// DescendantPIDs returns all child processes of pid, recursively.
+// pid itself is not included.
+func DescendantPIDs(pid int) []int {
+	var pids []int
+	queue := []int{pid}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		output, err := exec.Command("pgrep", "-P", strconv.Itoa(parent)).Output()
+		if err != nil {
+			continue
+		}
+		for _, field := range strings.Fields(string(output)) {
+			if child, err := strconv.Atoi(field); err == nil {
+				pids = append(pids, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return pids
+}
+
+// listeningPort returns the lowest TCP port any of the given processes listens on, or 0
+func listeningPort(pids []int) int {
+	if len(pids) == 0 {
+		return 0
+	}
+
+	pidList := make([]string, len(pids))
+	for i, pid := range pids {
+		pidList[i] = strconv.Itoa(pid)
+	}
+
+	// -a ANDs the filters; -Fn prints one "n<address>" line per socket
+	output, err := exec.Command("lsof", "-a", "-p", strings.Join(pidList, ","),
+		"-iTCP", "-sTCP:LISTEN", "-P", "-n", "-Fn").Output()
+	if err != nil && len(output) == 0 {
+		return 0
+	}
+	return parseLsofPort(string(output))
+}
+
+// parseLsofPort extracts the lowest port from `lsof -Fn` output such as "n*:3000" or "n[::1]:5173"
+func parseLsofPort(output string) int {
+	lowest := 0
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "n") {
+			continue
+		}
+		idx := strings.LastIndex(line, ":")
+		if idx == -1 {
+			continue
+		}
+		port, err := strconv.Atoi(line[idx+1:])
+		if err != nil {
+			continue
+		}
+		if lowest == 0 || port < lowest {
+			lowest = port
+		}
+	}
+	return lowest
+}
+
+// sessionWriter forwards output while recording its last line in the session
+type sessionWriter struct {
+	s *OctoSession
+	w io.Writer
+}
+
+func (sw sessionWriter) Write(p []byte) (int, error) {
+	lines := strings.Split(strings.TrimRight(string(p), "\r\n"), "\n")
+	sw.s.SetLastLog(lines[len(lines)-1])
+	return sw.w.Write(p)
+}
@@ -0,0 +1,163 @@
+package provisioner
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ToolVersionsFile is the file asdf reads pinned runtime versions from
+const ToolVersionsFile = ".tool-versions"
+
+// ToolVersion is one runtime pinned in .tool-versions
+type ToolVersion struct {
+	Name    string // asdf plugin name, e.g. nodejs, python, ruby
+	Version string // First (preferred) version listed for the tool
+}
+
+// FindToolVersionsFile returns the .tool-versions file asdf would use for projectPath,
+// searching the directory and its parents. It returns "" if there is none.
+func FindToolVersionsFile(projectPath string) string {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, ToolVersionsFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ParseToolVersions reads a .tool-versions file.
+// Entries that don't name an installed version (system, ref:, path:) are skipped.
+func ParseToolVersions(path string) ([]ToolVersion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var tools []ToolVersion
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = line[:idx]
+		}
+
+		// <tool> <version> [fallback versions...]
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		version := fields[1]
+		if version == "system" || strings.HasPrefix(version, "ref:") || strings.HasPrefix(version, "path:") {
+			continue
+		}
+		tools = append(tools, ToolVersion{Name: fields[0], Version: version})
+	}
+	return tools, scanner.Err()
+}
+
+// asdfDataDir returns where asdf keeps installed runtimes ($ASDF_DATA_DIR or ~/.asdf)
+func asdfDataDir() string {
+	if dir := os.Getenv("ASDF_DATA_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".asdf")
+}
+
+// AddAsdfToolPaths adds the bin directory of every runtime pinned in the project's
+// .tool-versions to the additional binary paths, so asdf-installed node, python, ruby, etc.
+// are found even when the shell was not set up for asdf. It returns the paths it added.
+func AddAsdfToolPaths(projectPath string) []string {
+	toolVersions := FindToolVersionsFile(projectPath)
+	dataDir := asdfDataDir()
+	if toolVersions == "" || dataDir == "" {
+		return nil
+	}
+
+	tools, err := ParseToolVersions(toolVersions)
+	if err != nil {
+		return nil
+	}
+
+	var added []string
+	for _, tool := range tools {
+		binDir := filepath.Join(dataDir, "installs", tool.Name, tool.Version, "bin")
+		if info, err := os.Stat(binDir); err != nil || !info.IsDir() {
+			continue
+		}
+		AddBinaryPath(binDir)
+		added = append(added, binDir)
+	}
+	return added
+}
+
+// AsdfNodeVersion returns the Node version pinned for asdf-nodejs in the project's
+// .tool-versions, or "" if asdf does not manage Node for the project
+func AsdfNodeVersion(projectPath string) string {
+	toolVersions := FindToolVersionsFile(projectPath)
+	if toolVersions == "" {
+		return ""
+	}
+
+	tools, err := ParseToolVersions(toolVersions)
+	if err != nil {
+		return ""
+	}
+	for _, tool := range tools {
+		if tool.Name == "nodejs" || tool.Name == "node" {
+			return tool.Version
+		}
+	}
+	return ""
+}
+
+// AddAsdfShimPath adds asdf's shim directory to the additional binary paths when it is
+// not already on PATH, which is the case when octo is started from a GUI launcher
+// instead of a shell that sourced asdf. It returns the path it added, or "".
+func AddAsdfShimPath() string {
+	dataDir := asdfDataDir()
+	if dataDir == "" {
+		return ""
+	}
+
+	shims := filepath.Join(dataDir, "shims")
+	if info, err := os.Stat(shims); err != nil || !info.IsDir() {
+		return ""
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(dir) == shims {
+			return ""
+		}
+	}
+
+	AddBinaryPath(shims)
+	return shims
+}
+
+// LookPath searches the additional binary paths and then PATH for an executable
+func LookPath(name string) (string, error) {
+	for _, dir := range GetAdditionalPaths() {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return path, nil
+		}
+	}
+	return exec.LookPath(name)
+}
@@ -0,0 +1,94 @@
+package provisioner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lockFileHeaderLines is how far into a lock file its format version is searched for
+const lockFileHeaderLines = 20
+
+// lockFileVersionPatterns extract the format version from the header of each lock file
+var lockFileVersionPatterns = map[string]*regexp.Regexp{
+	// "lockfileVersion": 3,
+	"package-lock.json": regexp.MustCompile(`"lockfileVersion"\s*:\s*(\d+)`),
+	// lockfileVersion: '9.0' (older versions are unquoted, e.g. 5.4)
+	"pnpm-lock.yaml": regexp.MustCompile(`^lockfileVersion:\s*['"]?(\d+)`),
+	// __metadata:\n  version: 8 (Yarn Berry); Yarn Classic only has the "# yarn lockfile v1" comment
+	"yarn.lock": regexp.MustCompile(`^\s+version:\s*(\d+)|^# yarn lockfile v(\d+)`),
+}
+
+// detectLockFileVersion returns the format version of the project's lock file, or 0 if unknown
+func detectLockFileVersion(projectPath string, lockFile string) int {
+	pattern, ok := lockFileVersionPatterns[lockFile]
+	if !ok {
+		return 0
+	}
+
+	file, err := os.Open(filepath.Join(projectPath, lockFile))
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 0; line < lockFileHeaderLines && scanner.Scan(); line++ {
+		matches := pattern.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		for _, group := range matches[1:] {
+			if version, err := strconv.Atoi(group); err == nil {
+				return version
+			}
+		}
+	}
+	return 0
+}
+
+// IsCompatibleLockFile checks whether the installed package manager can read the project's lock file.
+// An older manager would ignore or rewrite a newer lock file and resolve different versions.
+// It returns false and the reason if the lock file needs a newer package manager.
+func IsCompatibleLockFile(info PackageManagerInfo) (bool, string) {
+	if info.LockFileVersion == 0 || !info.Installed {
+		return true, ""
+	}
+
+	major, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(info.Version, "v"), ".", 2)[0])
+	if err != nil {
+		return true, ""
+	}
+
+	required := 0
+	switch info.Manager {
+	case NPM:
+		// lockfileVersion 3 dropped the v1 section npm 6 relies on
+		if info.LockFileVersion >= 3 {
+			required = 7
+		}
+	case PNPM:
+		// pnpm 9 writes lockfile 9.0 and pnpm 8 writes 6.0
+		switch {
+		case info.LockFileVersion >= 9:
+			required = 9
+		case info.LockFileVersion >= 6:
+			required = 8
+		}
+	case Yarn:
+		// Yarn Berry lock files (__metadata version 4+) cannot be read by Yarn Classic
+		if info.LockFileVersion >= 4 {
+			required = 2
+		}
+	}
+
+	if required > 0 && major < required {
+		return false, fmt.Sprintf("%s uses lock file version %d, which needs %s %d+ (found %s)",
+			info.LockFile, info.LockFileVersion, info.Manager, required, info.Version)
+	}
+	return true, ""
+}
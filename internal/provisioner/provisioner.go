@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -207,31 +208,51 @@ const (
 	PNPM PackageManager = "pnpm"
 	Yarn PackageManager = "yarn"
 	Bun  PackageManager = "bun"
+	Rush PackageManager = "rush"
 )
 
 // PackageManagerInfo contains details about the detected package manager
 type PackageManagerInfo struct {
-	Manager        PackageManager
-	LockFile       string
-	InstallCommand []string
-	IsMonorepo     bool
-	Installed      bool
-	Version        string
+	Manager         PackageManager
+	LockFile        string
+	InstallCommand  []string
+	IsMonorepo      bool
+	Installed       bool
+	Version         string
+	LockFileVersion int    // Format version of the lock file (e.g. 3 for npm, 9 for pnpm), 0 if unknown
+	NpxFallback     bool   // True if a command had to run a node_modules/.bin tool through npx (or pnpm exec/bunx)
+	AsdfNodeVersion string // Node version pinned in .tool-versions when asdf manages Node, "" otherwise
 }
 
 // DetectPackageManager checks for lock files in the project root and returns
-// the appropriate package manager. Priority: pnpm > yarn > npm
+// the appropriate package manager. Priority: rush > pnpm > yarn > npm
 func DetectPackageManager(projectPath string) PackageManagerInfo {
 	info := PackageManagerInfo{
 		Manager:        NPM, // Default fallback
 		InstallCommand: []string{"npm", "install"},
 	}
 
+	// With asdf managing Node, node and the package managers are reached through its shims
+	if version := AsdfNodeVersion(projectPath); version != "" {
+		info.AsdfNodeVersion = version
+		AddAsdfShimPath()
+	}
+
+	// Rush owns installs for every project listed in rush.json, whatever manager it wraps
+	if _, err := os.Stat(filepath.Join(projectPath, "rush.json")); err == nil {
+		info.Manager = Rush
+		info.IsMonorepo = true
+		info.InstallCommand = []string{"rush", "install"}
+		info.Installed, info.Version = checkManagerInstalled("rush")
+		return info
+	}
+
 	// Check for pnpm-lock.yaml first (highest priority)
 	pnpmLockPath := filepath.Join(projectPath, "pnpm-lock.yaml")
 	if _, err := os.Stat(pnpmLockPath); err == nil {
 		info.Manager = PNPM
 		info.LockFile = "pnpm-lock.yaml"
+		info.LockFileVersion = detectLockFileVersion(projectPath, info.LockFile)
 		info.IsMonorepo = detectPnpmWorkspace(projectPath)
 
 		// Use recursive flag for monorepos
@@ -292,6 +313,7 @@ func DetectPackageManager(projectPath string) PackageManagerInfo {
 	if _, err := os.Stat(yarnLockPath); err == nil {
 		info.Manager = Yarn
 		info.LockFile = "yarn.lock"
+		info.LockFileVersion = detectLockFileVersion(projectPath, info.LockFile)
 		info.IsMonorepo = detectYarnWorkspace(projectPath)
 		info.InstallCommand = []string{"yarn", "install"}
 		info.Installed, info.Version = checkManagerInstalled("yarn")
@@ -300,6 +322,7 @@ func DetectPackageManager(projectPath string) PackageManagerInfo {
 
 	// Fallback to npm
 	info.LockFile = "package-lock.json"
+	info.LockFileVersion = detectLockFileVersion(projectPath, info.LockFile)
 	info.Installed, info.Version = checkManagerInstalled("npm")
 	return info
 }
@@ -354,21 +377,87 @@ func detectYarnWorkspace(projectPath string) bool {
 
 // detectBunWorkspace checks if this is a bun workspace/monorepo
 func detectBunWorkspace(projectPath string) bool {
-	packageJSONPath := filepath.Join(projectPath, "package.json")
-	if data, err := os.ReadFile(packageJSONPath); err == nil {
-		content := string(data)
-		// Bun workspaces are defined in package.json similar to yarn
-		if strings.Contains(content, "\"workspaces\"") {
-			return true
+	return len(BunWorkspaceGlobs(projectPath)) > 0
+}
+
+// BunWorkspaceGlobs returns the workspace globs from package.json.
+// Bun accepts either an array ("workspaces": ["packages/*"]) or an object with a
+// "packages" array (used together with dependency catalogs).
+func BunWorkspaceGlobs(projectPath string) []string {
+	data, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+
+	var globs []string
+	if err := json.Unmarshal(pkg.Workspaces, &globs); err == nil {
+		return globs
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(pkg.Workspaces, &object); err == nil {
+		return object.Packages
+	}
+	return nil
+}
+
+// BunWorkspacePackages maps each workspace package name to its directory.
+// Negated globs ("!packages/legacy") exclude directories matched earlier.
+func BunWorkspacePackages(projectPath string) map[string]string {
+	packages := make(map[string]string)
+	excluded := make(map[string]bool)
+
+	globs := BunWorkspaceGlobs(projectPath)
+	for _, glob := range globs {
+		if strings.HasPrefix(glob, "!") {
+			matches, _ := filepath.Glob(filepath.Join(projectPath, strings.TrimPrefix(glob, "!")))
+			for _, dir := range matches {
+				excluded[dir] = true
+			}
 		}
 	}
 
-	return false
+	for _, glob := range globs {
+		if strings.HasPrefix(glob, "!") {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(projectPath, glob))
+		for _, dir := range matches {
+			if excluded[dir] {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+			if err != nil {
+				continue
+			}
+			var pkg struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(data, &pkg) == nil && pkg.Name != "" {
+				packages[pkg.Name] = dir
+			}
+		}
+	}
+	return packages
 }
 
 // checkManagerInstalled checks if a package manager is installed and returns its version
 func checkManagerInstalled(manager string) (bool, string) {
-	cmd := exec.Command(manager, "--version")
+	// Also consider the additional binary paths (e.g. asdf shims missing from PATH)
+	path, err := LookPath(manager)
+	if err != nil {
+		return false, ""
+	}
+	cmd := exec.Command(path, "--version")
+	cmd.Env = BuildEnhancedEnvironment()
 	output, err := cmd.Output()
 	if err != nil {
 		return false, ""
@@ -410,21 +499,22 @@ func GetPackageManagerFromPackageJSON(projectPath string) string {
 }
 
 // ParsePackageManagerSpec parses a packageManager string like "pnpm@9.1.4"
-// Returns the manager name and version separately
-func ParsePackageManagerSpec(spec string) (manager string, version string) {
+// or "pnpm@9.1.4+sha256.abc123" (Corepack integrity hash).
+// Returns the manager name, version and integrity hash separately
+func ParsePackageManagerSpec(spec string) (manager string, version string, integrityHash string) {
 	if spec == "" {
-		return "", ""
+		return "", "", ""
 	}
 
-	// Match pattern like "pnpm@9.1.4" or "yarn@4.0.0"
-	re := regexp.MustCompile(`^([a-z]+)@(.+)$`)
+	// Match pattern like "pnpm@9.1.4", "yarn@4.0.0" or "pnpm@9.1.4+sha256.abc123"
+	re := regexp.MustCompile(`^([a-z]+)@([^+]+)(?:\+(.+))?$`)
 	matches := re.FindStringSubmatch(spec)
-	if len(matches) == 3 {
-		return matches[1], matches[2]
+	if len(matches) == 4 {
+		return matches[1], matches[2], matches[3]
 	}
 
 	// No version specified, just return the manager name
-	return spec, ""
+	return spec, "", ""
 }
 
 // CorepackResult represents the result of a corepack operation
@@ -477,6 +567,33 @@ func EnableCorepack(manager string) CorepackResult {
 	return result
 }
 
+// PrepareCorepack activates a pinned package manager version via corepack prepare.
+// The spec may include an integrity hash ("pnpm@9.1.4+sha256.abc123"), which
+// Corepack verifies when downloading the package manager.
+func PrepareCorepack(spec string) CorepackResult {
+	result := CorepackResult{
+		CorepackAvailable: isCommandAvailable("corepack"),
+	}
+
+	if !result.CorepackAvailable {
+		result.Error = errors.New("corepack is not available")
+		result.Message = "❌ Corepack is not available. Please install Node.js (which includes Corepack)"
+		return result
+	}
+
+	cmd := exec.Command("corepack", "prepare", spec, "--activate")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		result.Error = fmt.Errorf("corepack prepare %s failed: %w - %s", spec, err, string(output))
+		result.Message = fmt.Sprintf("❌ Failed to prepare %s via Corepack: %s", spec, strings.TrimSpace(string(output)))
+		return result
+	}
+
+	result.Success = true
+	result.Message = fmt.Sprintf("✅ Prepared %s via Corepack", spec)
+	return result
+}
+
 // isPermissionError checks if an error is a permission denied error
 func isPermissionError(err error) bool {
 	var pathErr *os.PathError
@@ -494,6 +611,7 @@ type EnsurePackageManagerResult struct {
 	EnabledViaCorepack bool
 	NeedsDownload      bool   // True if Corepack needs to download the PM on first use
 	PinnedVersion      string // Version from packageManager field, if any
+	IntegrityHash      string // Corepack integrity hash from packageManager field (e.g. "sha256.abc123"), if any
 	Error              error
 	UserMessage        string // Message to display to the user
 }
@@ -517,10 +635,11 @@ func EnsurePackageManager(projectPath string) EnsurePackageManagerResult {
 	// Check for packageManager field in package.json (version pinning)
 	pmSpec := GetPackageManagerFromPackageJSON(projectPath)
 	if pmSpec != "" {
-		specManager, specVersion := ParsePackageManagerSpec(pmSpec)
+		specManager, specVersion, specHash := ParsePackageManagerSpec(pmSpec)
 		if specVersion != "" {
 			result.PinnedVersion = specVersion
 		}
+		result.IntegrityHash = specHash
 		// If packageManager field specifies a different manager, prefer that
 		if specManager != "" {
 			switch specManager {
@@ -571,9 +690,19 @@ func EnsurePackageManager(projectPath string) EnsurePackageManagerResult {
 		result.NeedsDownload = true // Corepack will download on first use
 		result.UserMessage = fmt.Sprintf("✅ Enabled %s via Corepack", managerName)
 
-		// If there's a pinned version, Corepack will handle it automatically
+		// If there's a pinned version, prepare it up front so Corepack can verify
+		// the integrity hash (when given) before the package manager is first used
 		if result.PinnedVersion != "" {
-			result.UserMessage = fmt.Sprintf("✅ Enabled %s@%s via Corepack", managerName, result.PinnedVersion)
+			spec := managerName + "@" + result.PinnedVersion
+			if result.IntegrityHash != "" {
+				spec += "+" + result.IntegrityHash
+			}
+			if prepareResult := PrepareCorepack(spec); prepareResult.Success {
+				result.NeedsDownload = false
+				result.UserMessage = fmt.Sprintf("✅ Enabled %s@%s via Corepack", managerName, result.PinnedVersion)
+			} else {
+				result.UserMessage = fmt.Sprintf("✅ Enabled %s via Corepack\n%s", managerName, prepareResult.Message)
+			}
 		}
 
 		return result
@@ -591,6 +720,61 @@ func EnsurePackageManager(projectPath string) EnsurePackageManagerResult {
 	return result
 }
 
+// LocalToolRunner returns the command that runs a CLI installed in node_modules/.bin
+func LocalToolRunner(manager PackageManager) string {
+	switch manager {
+	case PNPM:
+		// pnpx is an alias for pnpm dlx since pnpm 7, which downloads instead of using the local copy
+		return "pnpm exec"
+	case Yarn:
+		return "yarn"
+	case Bun:
+		return "bunx"
+	default:
+		return "npx"
+	}
+}
+
+// envAssignmentPattern matches a shell variable assignment such as PORT=3000
+var envAssignmentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// ResolveLocalTools rewrites each step of a command (split on &&) whose tool is not on PATH
+// but is installed in the project's node_modules/.bin, e.g. "turbo run dev" -> "npx turbo run dev".
+// The returned info has NpxFallback set if anything was rewritten.
+func ResolveLocalTools(projectPath string, command string) (string, PackageManagerInfo) {
+	info := DetectPackageManager(projectPath)
+	runner := LocalToolRunner(info.Manager)
+
+	steps := strings.Split(command, "&&")
+	for i, step := range steps {
+		// Skip leading NAME=value assignments, e.g. "PORT=3000 next dev"
+		at := 0
+		tool := ""
+		for _, field := range strings.Fields(step) {
+			at += strings.Index(step[at:], field)
+			if !envAssignmentPattern.MatchString(field) {
+				tool = field
+				break
+			}
+			at += len(field)
+		}
+		if tool == "" {
+			continue
+		}
+		if _, err := LookPath(tool); err == nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, "node_modules", ".bin", tool)); err != nil {
+			continue
+		}
+		// Keep the surrounding whitespace so the rest of the command is untouched
+		steps[i] = step[:at] + runner + " " + step[at:]
+		info.NpxFallback = true
+	}
+
+	return strings.Join(steps, "&&"), info
+}
+
 // BunInstallResult represents the result of attempting to install Bun
 type BunInstallResult struct {
 	Success      bool
@@ -781,6 +965,170 @@ func EnsureBunWithFallback(projectPath string, reader *bufio.Reader) EnsureBunRe
 	return result
 }
 
+// EnsureManagerResult is the result of EnsureBundler and EnsurePoetry
+type EnsureManagerResult struct {
+	Available   bool
+	Error       error
+	UserMessage string
+}
+
+// EnsureBundler checks for Bundler and offers to install it via `gem install bundler`.
+// Pass nil for reader to use os.Stdin
+func EnsureBundler(reader *bufio.Reader) EnsureManagerResult {
+	if isCommandAvailable("bundle") {
+		return EnsureManagerResult{Available: true}
+	}
+
+	if !isCommandAvailable("gem") {
+		return EnsureManagerResult{
+			Error:       errors.New("bundler is not installed"),
+			UserMessage: "❌ Bundler is required but RubyGems (gem) was not found. Please install Ruby from https://www.ruby-lang.org/",
+		}
+	}
+
+	return ensureManager(reader, "Bundler", "bundle", "gem install bundler")
+}
+
+// EnsurePoetry checks for Poetry and offers to install it via pipx (preferred) or pip.
+// Pass nil for reader to use os.Stdin
+func EnsurePoetry(reader *bufio.Reader) EnsureManagerResult {
+	if isCommandAvailable("poetry") {
+		return EnsureManagerResult{Available: true}
+	}
+
+	var installCommand string
+	switch {
+	case isCommandAvailable("pipx"):
+		installCommand = "pipx install poetry"
+	case isCommandAvailable("pip3"):
+		installCommand = "pip3 install --user poetry"
+	case isCommandAvailable("pip"):
+		installCommand = "pip install --user poetry"
+	default:
+		return EnsureManagerResult{
+			Error:       errors.New("poetry is not installed"),
+			UserMessage: "❌ Poetry is required but neither pipx nor pip was found.\n   To install manually: curl -sSL https://install.python-poetry.org | python3 -",
+		}
+	}
+
+	return ensureManager(reader, "Poetry", "poetry", installCommand)
+}
+
+// ensureManager prompts the user to install a missing package manager and runs the install command.
+// Tools installed with --user/pipx land in ~/.local/bin, which is added to PATH for this session.
+func ensureManager(reader *bufio.Reader, name string, binary string, installCommand string) EnsureManagerResult {
+	if reader == nil {
+		reader = bufio.NewReader(os.Stdin)
+	}
+
+	if !PromptUserForManagerInstall(reader, name, installCommand) {
+		return EnsureManagerResult{
+			Error:       fmt.Errorf("%s is required but not installed", strings.ToLower(name)),
+			UserMessage: fmt.Sprintf("❌ %s is required but not installed.\n   To install manually: %s", name, installCommand),
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("⏳ Installing %s...\n", name)
+
+	parts := strings.Fields(installCommand)
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return EnsureManagerResult{
+			Error:       fmt.Errorf("failed to install %s: %w", strings.ToLower(name), err),
+			UserMessage: fmt.Sprintf("❌ Failed to install %s. Please try manually: %s", name, installCommand),
+		}
+	}
+
+	if !isCommandAvailable(binary) {
+		localBin := filepath.Join(os.Getenv("HOME"), ".local", "bin")
+		if _, err := os.Stat(filepath.Join(localBin, binary)); err == nil {
+			os.Setenv("PATH", localBin+":"+os.Getenv("PATH"))
+			AddBinaryPath(localBin)
+		}
+	}
+
+	return EnsureManagerResult{
+		Available:   true,
+		UserMessage: fmt.Sprintf("✅ %s installed successfully!", name),
+	}
+}
+
+// goTools maps Go CLI tools that are installed automatically when a run command needs them
+var goTools = map[string]string{
+	"air": "github.com/air-verse/air@latest",
+}
+
+// IsGoTool reports whether name is a Go tool that InstallGoTool can install
+func IsGoTool(name string) bool {
+	_, ok := goTools[name]
+	return ok
+}
+
+// InstallGoTool installs a Go CLI tool with `go install` and adds the Go bin directory to PATH.
+// Installer output is written to output.
+func InstallGoTool(name string, output io.Writer) error {
+	pkg, ok := goTools[name]
+	if !ok {
+		return fmt.Errorf("unknown Go tool %q", name)
+	}
+	if !isCommandAvailable("go") {
+		return errors.New("go is not installed")
+	}
+
+	cmd := exec.Command("go", "install", pkg)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go install %s failed: %w", pkg, err)
+	}
+
+	if !isCommandAvailable(name) {
+		binDir := goBinDir()
+		if _, err := os.Stat(filepath.Join(binDir, name)); err != nil {
+			return fmt.Errorf("%s was installed but not found in %s", name, binDir)
+		}
+		os.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		AddBinaryPath(binDir)
+	}
+	return nil
+}
+
+// goBinDir returns the directory `go install` writes binaries to
+func goBinDir() string {
+	if output, err := exec.Command("go", "env", "GOBIN").Output(); err == nil {
+		if dir := strings.TrimSpace(string(output)); dir != "" {
+			return dir
+		}
+	}
+	if output, err := exec.Command("go", "env", "GOPATH").Output(); err == nil {
+		if dir := strings.TrimSpace(string(output)); dir != "" {
+			return filepath.Join(dir, "bin")
+		}
+	}
+	return filepath.Join(os.Getenv("HOME"), "go", "bin")
+}
+
+// PromptUserForManagerInstall asks the user if they want to install a missing package manager
+// Returns true if user wants to install, false otherwise
+func PromptUserForManagerInstall(reader *bufio.Reader, name string, installCommand string) bool {
+	fmt.Println()
+	fmt.Printf("⚠️  %s is required but not installed.\n", name)
+	fmt.Println("   Would you like to install it now?")
+	fmt.Printf("   Command: %s\n", installCommand)
+	fmt.Printf("\n   Install %s? [y/N]: ", name)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
 // ValidateRuntimeBeforeInstall validates that the required runtime binary exists before running install
 // Returns an error with actionable fix instructions if the binary is missing
 func ValidateRuntimeBeforeInstall(projectPath string) error {
@@ -827,6 +1175,8 @@ func getFixCommand(manager PackageManager) string {
 		return "corepack enable yarn"
 	case NPM:
 		return "Install Node.js from https://nodejs.org"
+	case Rush:
+		return "npm install -g @microsoft/rush"
 	default:
 		return ""
 	}
@@ -902,6 +1252,8 @@ func Check(projectPath string) CheckResult {
 			result.InstallHint = "This project requires bun. Please install it from https://bun.sh or run 'curl -fsSL https://bun.sh/install | bash'"
 		case NPM:
 			result.InstallHint = "npm is required. Please install Node.js from https://nodejs.org"
+		case Rush:
+			result.InstallHint = "This project requires Rush. Please run 'npm install -g @microsoft/rush' to continue."
 		}
 	}
 
@@ -926,6 +1278,11 @@ func InstallDependencies(projectPath string) error {
 		return fmt.Errorf("%s is not installed. %s", info.Manager, getInstallHint(info.Manager))
 	}
 
+	// An older package manager would silently rewrite a newer lock file
+	if ok, reason := IsCompatibleLockFile(info); !ok {
+		fmt.Printf("⚠️  Warning: %s\n", reason)
+	}
+
 	// Build the command
 	if len(info.InstallCommand) == 0 {
 		return fmt.Errorf("no install command configured for %s", info.Manager)
@@ -982,6 +1339,8 @@ func getInstallHint(manager PackageManager) string {
 		return "Please install bun from https://bun.sh or run 'curl -fsSL https://bun.sh/install | bash'"
 	case NPM:
 		return "Please install Node.js from https://nodejs.org"
+	case Rush:
+		return "Please run 'npm install -g @microsoft/rush' to continue."
 	default:
 		return ""
 	}
@@ -1004,6 +1363,8 @@ func GetManagerName(manager PackageManager) string {
 		return "Bun"
 	case NPM:
 		return "npm"
+	case Rush:
+		return "Rush"
 	default:
 		return string(manager)
 	}
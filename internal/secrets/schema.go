@@ -0,0 +1,165 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvSchema is a JSON Schema describing environment variable values.
+// Env vars are validated as the properties of an object; the supported keywords are
+// type, enum, const, pattern, minLength, maxLength, minimum, maximum and format (uri, email).
+type EnvSchema struct {
+	Required   []string                     `json:"required"`
+	Properties map[string]EnvSchemaProperty `json:"properties"`
+}
+
+// EnvSchemaProperty constrains the value of one environment variable
+type EnvSchemaProperty struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Enum        []any    `json:"enum"`
+	Const       any      `json:"const"`
+	Pattern     string   `json:"pattern"`
+	MinLength   *int     `json:"minLength"`
+	MaxLength   *int     `json:"maxLength"`
+	Minimum     *float64 `json:"minimum"`
+	Maximum     *float64 `json:"maximum"`
+	Format      string   `json:"format"`
+
+	pattern *regexp.Regexp
+}
+
+// emailPattern is a deliberately loose check for the "email" format
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// LoadEnvSchema reads a JSON Schema file for environment variables
+func LoadEnvSchema(path string) (*EnvSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema EnvSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid env var schema %s: %w", path, err)
+	}
+
+	for name, prop := range schema.Properties {
+		if prop.Pattern == "" {
+			continue
+		}
+		prop.pattern, err = regexp.Compile(prop.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for %s in %s: %w", name, path, err)
+		}
+		schema.Properties[name] = prop
+	}
+	return &schema, nil
+}
+
+// Validate checks the env var values returned by lookup against the schema.
+// It returns one message per violation, sorted by variable name; unset variables
+// are only reported when the schema lists them as required.
+func (s *EnvSchema) Validate(lookup func(name string) (string, bool)) []string {
+	var problems []string
+
+	for _, name := range s.Required {
+		if value, ok := lookup(name); !ok || value == "" {
+			problems = append(problems, fmt.Sprintf("%s is required by the schema but not set", name))
+		}
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := s.Properties[name].validate(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %v", name, err))
+		}
+	}
+	return problems
+}
+
+// validate checks a single value; env values are strings, so numeric and boolean
+// types are checked by parsing
+func (p EnvSchemaProperty) validate(value string) error {
+	var number float64
+	isNumber := false
+
+	switch p.Type {
+	case "", "string":
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer (got %q)", value)
+		}
+		number, isNumber = float64(n), true
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number (got %q)", value)
+		}
+		number, isNumber = n, true
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be a boolean (got %q)", value)
+		}
+	default:
+		return fmt.Errorf("has unsupported schema type %q", p.Type)
+	}
+
+	if p.Const != nil && fmt.Sprint(p.Const) != value {
+		return fmt.Errorf("must be %v", p.Const)
+	}
+	if len(p.Enum) > 0 {
+		allowed := make([]string, len(p.Enum))
+		found := false
+		for i, e := range p.Enum {
+			allowed[i] = fmt.Sprint(e)
+			found = found || allowed[i] == value
+		}
+		if !found {
+			return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+	if p.pattern != nil && !p.pattern.MatchString(value) {
+		return fmt.Errorf("does not match pattern %s", p.Pattern)
+	}
+	if p.MinLength != nil && len(value) < *p.MinLength {
+		return fmt.Errorf("must be at least %d characters", *p.MinLength)
+	}
+	if p.MaxLength != nil && len(value) > *p.MaxLength {
+		return fmt.Errorf("must be at most %d characters", *p.MaxLength)
+	}
+	if isNumber && p.Minimum != nil && number < *p.Minimum {
+		return fmt.Errorf("must be at least %v", *p.Minimum)
+	}
+	if isNumber && p.Maximum != nil && number > *p.Maximum {
+		return fmt.Errorf("must be at most %v", *p.Maximum)
+	}
+
+	switch p.Format {
+	case "uri", "url":
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return fmt.Errorf("must be a URI")
+		}
+	case "email":
+		if !emailPattern.MatchString(value) {
+			return fmt.Errorf("must be an email address")
+		}
+	}
+	return nil
+}
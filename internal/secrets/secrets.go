@@ -2,23 +2,53 @@ package secrets
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
+	"unicode"
 )
 
+// EnvVarSource records where an environment variable was discovered
+type EnvVarSource int
+
+const (
+	SourceCode     EnvVarSource = iota // Referenced in source code
+	SourceReadme                       // Documented in the README
+	SourceExample                      // Listed in .env.example or a similar template
+	SourceExisting                     // Already defined in an existing .env file
+)
+
+// String returns a human-readable label for the source
+func (s EnvVarSource) String() string {
+	switch s {
+	case SourceReadme:
+		return "README"
+	case SourceExample:
+		return ".env.example"
+	case SourceExisting:
+		return "existing .env"
+	default:
+		return "code"
+	}
+}
+
 // EnvVar represents a detected environment variable
 type EnvVar struct {
 	Name         string
-	File         string // File where it was found
-	Line         int    // Line number where it was found
-	Language     string // Language/pattern that matched
-	Required     bool   // Whether the variable is required (true by default)
-	DefaultValue string // Default or suggested value from README/example files
-	TargetDir    string // Target directory for the .env file (e.g., "apps/client")
+	File         string       // File where it was found
+	Line         int          // Line number where it was found
+	Language     string       // Language/pattern that matched
+	Required     bool         // Whether the variable is required (true by default)
+	DefaultValue string       // Default or suggested value from README/example files
+	TargetDir    string       // Target directory for the .env file (e.g., "apps/client")
+	Source       EnvVarSource // Where the variable (and its default value) was discovered
 }
 
 // ReadmeEnvConfig represents environment variable configuration from README
@@ -27,6 +57,8 @@ type ReadmeEnvConfig struct {
 	Value        string
 	TargetDir    string // Where to write this env var (e.g., "apps/client", "apps/server")
 	Description  string // Optional description from README context
+	Required     bool   // Whether a README table marks the variable as required
+	HasRequired  bool   // Whether a README table states either way (Required is meaningful)
 }
 
 // EnvFileTarget represents a target .env file with its variables
@@ -53,6 +85,9 @@ var envPatterns = map[string]*regexp.Regexp{
 	// JavaScript/TypeScript: process.env.VAR_NAME or process.env['VAR_NAME']
 	"node": regexp.MustCompile(`process\.env\.([A-Z][A-Z0-9_]*)|process\.env\[['"]([A-Z][A-Z0-9_]*)['"]\]`),
 
+	// Vite and Astro: import.meta.env.VITE_VAR or import.meta.env.PUBLIC_VAR
+	"vite": regexp.MustCompile(`import\.meta\.env\.([A-Z][A-Z0-9_]*)`),
+
 	// Python: os.environ['VAR'], os.environ.get('VAR'), os.getenv('VAR')
 	"python": regexp.MustCompile(`os\.environ(?:\.get)?\[?['\"]([A-Z][A-Z0-9_]*)['"]\]?|os\.getenv\(['\"]([A-Z][A-Z0-9_]*)['"]\)`),
 
@@ -68,18 +103,40 @@ var envPatterns = map[string]*regexp.Regexp{
 	// Rust: std::env::var("VAR") or env::var("VAR")
 	"rust": regexp.MustCompile(`(?:std::)?env::var\(['\"]([A-Z][A-Z0-9_]*)['"]\)`),
 
+	// Kotlin: System.getenv("VAR"), getenv("VAR") or BuildConfig.VAR (Android)
+	"kotlin": regexp.MustCompile(`(?:System\.)?getenv\(['\"]([A-Z][A-Z0-9_]*)['"]\)|BuildConfig\.([A-Z][A-Z0-9_]*)`),
+
+	// Swift: ProcessInfo.processInfo.environment["VAR"]
+	"swift": regexp.MustCompile(`environment\[['\"]([A-Z][A-Z0-9_]*)['"]\]`),
+
 	// Generic .env reference pattern (for config files)
 	"generic": regexp.MustCompile(`\$\{([A-Z][A-Z0-9_]*)\}|\$([A-Z][A-Z0-9_]*)`),
 }
 
 // File extensions to scan for each language
 var languageExtensions = map[string][]string{
-	"node":   {".js", ".ts", ".jsx", ".tsx", ".mjs", ".cjs"},
+	"node":   {".js", ".ts", ".jsx", ".tsx", ".mjs", ".cjs", ".astro"},
 	"python": {".py"},
 	"java":   {".java"},
 	"go":     {".go"},
 	"ruby":   {".rb"},
 	"rust":   {".rs"},
+	"swift":  {".swift"},
+	"kotlin": {".kt", ".kts"},
+}
+
+// patternExtensions limits patterns to the file types they can appear in; other patterns apply to every scanned file
+var patternExtensions = map[string][]string{
+	"vite": {".ts", ".tsx", ".js", ".jsx", ".astro"},
+}
+
+// viteBuiltinEnvVars are set by Vite itself on import.meta.env
+var viteBuiltinEnvVars = map[string]bool{
+	"MODE":     true,
+	"BASE_URL": true,
+	"PROD":     true,
+	"DEV":      true,
+	"SSR":      true,
 }
 
 // Common env vars to ignore (usually system-provided)
@@ -152,8 +209,48 @@ func isValidEnvVarName(name string) bool {
 	return strings.Contains(name, "_")
 }
 
+// ScanOptions limits how much of a project ScanForEnvVarsWithOptions reads.
+// Zero limits mean unlimited.
+type ScanOptions struct {
+	// MaxFileSizeBytes skips files larger than this (e.g. bundle.js, vendor.min.js)
+	MaxFileSizeBytes int64
+	// MaxLines stops scanning a file after this many lines
+	MaxLines int
+	// ExcludePatterns are globs for paths to skip, matched against the path relative
+	// to the project and against the file or directory name (e.g. "*.min.js", "public/assets")
+	ExcludePatterns []string
+}
+
+// DefaultScanOptions returns the limits used by ScanForEnvVars
+func DefaultScanOptions() ScanOptions {
+	return ScanOptions{
+		MaxFileSizeBytes: 1024 * 1024,
+		MaxLines:         10000,
+	}
+}
+
+// excluded reports whether relPath matches one of the exclude patterns
+func (opts ScanOptions) excluded(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	for _, pattern := range opts.ExcludePatterns {
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.Base(relPath)); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // ScanForEnvVars scans the project directory for environment variable usage
 func ScanForEnvVars(projectPath string, language string) ([]EnvVar, error) {
+	return ScanForEnvVarsWithOptions(projectPath, language, DefaultScanOptions())
+}
+
+// ScanForEnvVarsWithOptions scans the project directory for environment variable usage,
+// skipping excluded paths and files over the size limit
+func ScanForEnvVarsWithOptions(projectPath string, language string, opts ScanOptions) ([]EnvVar, error) {
 	var envVars []EnvVar
 	seen := make(map[string]bool)
 
@@ -166,6 +263,13 @@ func ScanForEnvVars(projectPath string, language string) ([]EnvVar, error) {
 			return nil // Skip files we can't access
 		}
 
+		if relPath, err := filepath.Rel(projectPath, path); err == nil && relPath != "." && opts.excluded(relPath) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Skip common non-source directories
 		if info.IsDir() {
 			name := info.Name()
@@ -183,8 +287,13 @@ func ScanForEnvVars(projectPath string, language string) ([]EnvVar, error) {
 			return nil
 		}
 
+		// Large files are almost always generated (bundles, minified vendor code)
+		if opts.MaxFileSizeBytes > 0 && info.Size() > opts.MaxFileSizeBytes {
+			return nil
+		}
+
 		// Scan the file
-		fileVars, err := scanFile(path, patterns)
+		fileVars, err := scanFile(path, patternsForExt(patterns, ext), opts.MaxLines)
 		if err != nil {
 			return nil // Skip files we can't read
 		}
@@ -217,15 +326,26 @@ func ScanForEnvVars(projectPath string, language string) ([]EnvVar, error) {
 		// (contains KEY, SECRET, TOKEN, PASSWORD, etc.) AND has no default
 		if isCriticalEnvVar(envVars[i].Name) {
 			// Check if it has a default in example file
-			if hasDefault, ok := defaults[envVars[i].Name]; !ok || !hasDefault {
+			if _, ok := defaults[envVars[i].Name]; !ok {
 				envVars[i].Required = true
 			}
 		}
 
+		// Example files hold values the project authors expect to work locally
+		if value, ok := defaults[envVars[i].Name]; ok {
+			envVars[i].DefaultValue = value
+			envVars[i].Source = SourceExample
+		}
+
 		// Heuristic: KUBECONFIG is always optional if local config exists
 		if envVars[i].Name == "KUBECONFIG" && hasKubeConfig {
 			envVars[i].Required = false
 		}
+
+		// Vite only exposes VITE_* vars from the .env of the package it builds
+		if strings.HasPrefix(envVars[i].Name, "VITE_") {
+			envVars[i].TargetDir = packageDirOf(envVars[i].File, projectPath)
+		}
 	}
 
 	// Sort by name for consistent output
@@ -236,9 +356,9 @@ func ScanForEnvVars(projectPath string, language string) ([]EnvVar, error) {
 	return envVars, nil
 }
 
-// checkEnvExample looks for example env files and returns vars with defaults
-func checkEnvExample(root string) map[string]bool {
-	defaults := make(map[string]bool)
+// checkEnvExample looks for example env files and returns the non-empty default values
+func checkEnvExample(root string) map[string]string {
+	defaults := make(map[string]string)
 	candidates := []string{".env.example", ".env.sample", ".env.template", ".env.defaults"}
 
 	for _, name := range candidates {
@@ -246,8 +366,8 @@ func checkEnvExample(root string) map[string]bool {
 		vars, err := ReadEnvFile(path)
 		if err == nil {
 			for k, v := range vars {
-				if v != "" {
-					defaults[k] = true
+				if _, seen := defaults[k]; !seen && v != "" {
+					defaults[k] = v
 				}
 			}
 		}
@@ -281,11 +401,47 @@ func getPatterns(language string) map[string]*regexp.Regexp {
 	// For Node, also check for generic patterns in config files
 	if lang == "node" || lang == "unknown" {
 		patterns["node"] = envPatterns["node"]
+		patterns["vite"] = envPatterns["vite"]
 	}
 
 	return patterns
 }
 
+// patternsForExt drops the patterns that don't apply to files with extension ext
+func patternsForExt(patterns map[string]*regexp.Regexp, ext string) map[string]*regexp.Regexp {
+	filtered := make(map[string]*regexp.Regexp, len(patterns))
+	for lang, pattern := range patterns {
+		exts, restricted := patternExtensions[lang]
+		applies := !restricted
+		for _, e := range exts {
+			if e == ext {
+				applies = true
+				break
+			}
+		}
+		if applies {
+			filtered[lang] = pattern
+		}
+	}
+	return filtered
+}
+
+// packageDirOf returns the directory of the package.json closest to file, relative to projectPath,
+// or "" when that is the project root
+func packageDirOf(file string, projectPath string) string {
+	dir := filepath.Dir(file)
+	for {
+		rel, err := filepath.Rel(projectPath, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return ""
+		}
+		if _, err := os.Stat(filepath.Join(dir, "package.json")); err == nil {
+			return rel
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
 // shouldScanFile checks if a file should be scanned based on extension
 func shouldScanFile(ext string, language string) bool {
 	lang := strings.ToLower(language)
@@ -317,8 +473,9 @@ func shouldScanFile(ext string, language string) bool {
 	return false
 }
 
-// scanFile scans a single file for environment variable usage
-func scanFile(path string, patterns map[string]*regexp.Regexp) ([]EnvVar, error) {
+// scanFile scans a single file for environment variable usage.
+// If maxLines > 0, only the first maxLines lines are read.
+func scanFile(path string, patterns map[string]*regexp.Regexp, maxLines int) ([]EnvVar, error) {
 	var vars []EnvVar
 
 	file, err := os.Open(path)
@@ -332,6 +489,9 @@ func scanFile(path string, patterns map[string]*regexp.Regexp) ([]EnvVar, error)
 
 	for scanner.Scan() {
 		lineNum++
+		if maxLines > 0 && lineNum > maxLines {
+			break
+		}
 		line := scanner.Text()
 
 		for lang, pattern := range patterns {
@@ -345,6 +505,9 @@ func scanFile(path string, patterns map[string]*regexp.Regexp) ([]EnvVar, error)
 						break
 					}
 				}
+				if lang == "vite" && viteBuiltinEnvVars[varName] {
+					continue
+				}
 				// Skip single-letter or too-short variable names (likely false positives)
 				if varName != "" && len(varName) >= 3 && isValidEnvVarName(varName) {
 					vars = append(vars, EnvVar{
@@ -352,6 +515,7 @@ func scanFile(path string, patterns map[string]*regexp.Regexp) ([]EnvVar, error)
 						File:     path,
 						Line:     lineNum,
 						Language: lang,
+						Source:   SourceCode,
 					})
 				}
 			}
@@ -361,7 +525,8 @@ func scanFile(path string, patterns map[string]*regexp.Regexp) ([]EnvVar, error)
 	return vars, scanner.Err()
 }
 
-// ReadEnvFile reads an .env file and returns defined variables
+// ReadEnvFile reads an .env file and returns defined variables.
+// Double-quoted values may span multiple lines and support \n, \" and \\ escapes.
 func ReadEnvFile(envPath string) (map[string]string, error) {
 	vars := make(map[string]string)
 
@@ -374,9 +539,21 @@ func ReadEnvFile(envPath string) (map[string]string, error) {
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	return parseEnvVars(file)
+}
+
+// parseEnvVars parses KEY=value lines in .env format
+func parseEnvVars(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		lines = append(lines, scanner.Text())
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
 
 		// Skip comments and empty lines
 		if line == "" || strings.HasPrefix(line, "#") {
@@ -385,18 +562,90 @@ func ReadEnvFile(envPath string) (map[string]string, error) {
 
 		// Parse KEY=value
 		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			// Remove quotes if present
-			value = strings.Trim(value, `"'`)
-			vars[key] = value
+		if len(parts) != 2 {
+			continue
 		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		// Double-quoted values may span multiple lines until the closing quote
+		if strings.HasPrefix(value, `"`) {
+			raw := value[1:]
+			end := i
+			for !hasClosingQuote(raw) && end+1 < len(lines) {
+				end++
+				raw += "\n" + lines[end]
+			}
+			if hasClosingQuote(raw) {
+				vars[key] = unquoteEnvValue(raw)
+				i = end
+				continue
+			}
+			// No closing quote before EOF: keep the value to its own line so a typo
+			// doesn't swallow the variables after it
+			value = value[1:]
+		}
+
+		// Remove quotes if present
+		value = strings.Trim(value, `"'`)
+		vars[key] = value
 	}
 
 	return vars, scanner.Err()
 }
 
+// hasClosingQuote reports whether s contains an unescaped double quote
+func hasClosingQuote(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return true
+		}
+	}
+	return false
+}
+
+// unquoteEnvValue decodes the body of a double-quoted value up to its closing quote,
+// expanding \n, \" and \\ escape sequences
+func unquoteEnvValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			break
+		}
+		if c == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case '"', '\\':
+				b.WriteByte(s[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// formatEnvValue quotes values that contain spaces or special characters.
+// Newlines are written literally inside double quotes so multiline secrets
+// (certificates, SSH keys) stay readable in the .env file.
+func formatEnvValue(v string) string {
+	if !strings.ContainsAny(v, " \t\n\"'\\") {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return `"` + v + `"`
+}
+
 // CheckEnvStatus checks which required env vars are defined
 func CheckEnvStatus(projectPath string, language string) (EnvStatus, error) {
 	status := EnvStatus{
@@ -451,12 +700,16 @@ func CheckEnvStatus(projectPath string, language string) (EnvStatus, error) {
 	}
 
 	// Find missing vars and determine their target directories
-	for _, v := range required {
-		if !status.Defined[v.Name] {
-			// Determine target directory based on where the var was found
+	for i, v := range required {
+		if status.Defined[v.Name] {
+			status.Required[i].Source = SourceExisting
+			continue
+		}
+		// Determine target directory based on where the var was found
+		if v.TargetDir == "" {
 			v.TargetDir = determineTargetDirFromFile(v.File, projectPath)
-			status.Missing = append(status.Missing, v)
 		}
+		status.Missing = append(status.Missing, v)
 	}
 
 	return status, nil
@@ -501,17 +754,12 @@ func WriteEnvFile(envPath string, values map[string]string) error {
 		existingVars[k] = v
 	}
 
-	// Create the file
-	file, err := os.Create(envPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	var buf bytes.Buffer
 
 	// Write header comment
-	fmt.Fprintln(file, "# Environment variables for this project")
-	fmt.Fprintln(file, "# Generated by Octo CLI")
-	fmt.Fprintln(file, "")
+	fmt.Fprintln(&buf, "# Environment variables for this project")
+	fmt.Fprintln(&buf, "# Generated by Octo CLI")
+	fmt.Fprintln(&buf, "")
 
 	// Sort keys for consistent output
 	var keys []string
@@ -522,15 +770,80 @@ func WriteEnvFile(envPath string, values map[string]string) error {
 
 	// Write variables
 	for _, k := range keys {
-		v := existingVars[k]
 		// Quote values that contain spaces or special characters
-		if strings.ContainsAny(v, " \t\n\"'") {
-			v = fmt.Sprintf(`"%s"`, strings.ReplaceAll(v, `"`, `\"`))
+		fmt.Fprintf(&buf, "%s=%s\n", k, formatEnvValue(existingVars[k]))
+	}
+
+	return writeFileAtomic(envPath, buf.Bytes())
+}
+
+// writeFileAtomic replaces path with data without ever leaving a truncated file behind.
+// The data is written and synced to a temp file in the same directory, then renamed over path.
+// An existing file keeps its permissions (.env files are often 0600).
+func writeFileAtomic(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// Clean up the temp file on any failure; after a successful rename it no longer exists
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// ============================================================================
+// Doppler Secret Source
+// ============================================================================
+
+// DopplerConfigured reports whether the project is set up for Doppler:
+// the doppler CLI is installed and a doppler.yaml exists in the project root.
+func DopplerConfigured(projectPath string) bool {
+	if _, err := os.Stat(filepath.Join(projectPath, "doppler.yaml")); err != nil {
+		return false
+	}
+	_, err := exec.LookPath("doppler")
+	return err == nil
+}
+
+// LoadDopplerSecrets downloads the project's secrets from Doppler without
+// writing them to disk and returns them as a map
+func LoadDopplerSecrets(projectPath string) (map[string]string, error) {
+	cmd := exec.Command("doppler", "secrets", "download", "--no-file", "--format", "env")
+	cmd.Dir = projectPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("doppler secrets download failed: %s", msg)
 		}
-		fmt.Fprintf(file, "%s=%s\n", k, v)
+		return nil, fmt.Errorf("doppler secrets download failed: %w", err)
 	}
 
-	return nil
+	return parseEnvVars(bytes.NewReader(output))
 }
 
 // ============================================================================
@@ -820,23 +1133,33 @@ func isPlaceholder(val string) bool {
 	return false
 }
 
+// envVarsCacheEntry is a GetAllEnvVars result along with the file state it was built from
+type envVarsCacheEntry struct {
+	fingerprint string
+	vars        map[string]string
+}
+
+// envVarsCache holds GetAllEnvVars results per project path for the lifetime of the process
+var (
+	envVarsCacheMu sync.Mutex
+	envVarsCache   = make(map[string]envVarsCacheEntry)
+)
+
 // GetAllEnvVars collects all environment variables from .env files and templates
-// This is used for global injection into command environments
+// This is used for global injection into command environments.
+// Results are cached until one of the .env files is created, removed or modified.
 func GetAllEnvVars(projectPath string) map[string]string {
-	allVars := make(map[string]string)
+	envPaths := globalEnvFilePaths(projectPath)
+	fingerprint := envFilesFingerprint(envPaths)
 
-	// Define all paths to check for .env files
-	envPaths := []string{
-		filepath.Join(projectPath, ".env"),
-		filepath.Join(projectPath, ".env.local"),
-		filepath.Join(projectPath, "apps/client/.env"),
-		filepath.Join(projectPath, "apps/server/.env"),
-		filepath.Join(projectPath, "apps/web/.env"),
-		filepath.Join(projectPath, "apps/api/.env"),
-		filepath.Join(projectPath, "client/.env"),
-		filepath.Join(projectPath, "server/.env"),
+	envVarsCacheMu.Lock()
+	defer envVarsCacheMu.Unlock()
+
+	if entry, ok := envVarsCache[projectPath]; ok && entry.fingerprint == fingerprint {
+		return copyEnvVars(entry.vars)
 	}
 
+	allVars := make(map[string]string)
 	for _, envPath := range envPaths {
 		if vars, err := ReadEnvFile(envPath); err == nil {
 			for k, v := range vars {
@@ -847,7 +1170,46 @@ func GetAllEnvVars(projectPath string) map[string]string {
 		}
 	}
 
-	return allVars
+	envVarsCache[projectPath] = envVarsCacheEntry{fingerprint: fingerprint, vars: allVars}
+	return copyEnvVars(allVars)
+}
+
+// envFilesFingerprint summarizes the modification time and size of each file,
+// so any change to the .env files invalidates the cache
+func envFilesFingerprint(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			b.WriteString("-;")
+			continue
+		}
+		fmt.Fprintf(&b, "%d:%d;", info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
+
+// copyEnvVars returns a copy so callers can't modify the cached map
+func copyEnvVars(vars map[string]string) map[string]string {
+	result := make(map[string]string, len(vars))
+	for k, v := range vars {
+		result[k] = v
+	}
+	return result
+}
+
+// globalEnvFilePaths lists the .env files GetAllEnvVars reads, in priority order
+func globalEnvFilePaths(projectPath string) []string {
+	return []string{
+		filepath.Join(projectPath, ".env"),
+		filepath.Join(projectPath, ".env.local"),
+		filepath.Join(projectPath, "apps/client/.env"),
+		filepath.Join(projectPath, "apps/server/.env"),
+		filepath.Join(projectPath, "apps/web/.env"),
+		filepath.Join(projectPath, "apps/api/.env"),
+		filepath.Join(projectPath, "client/.env"),
+		filepath.Join(projectPath, "server/.env"),
+	}
 }
 
 // AppendToEnvFile appends new values to an existing .env file
@@ -877,11 +1239,7 @@ func AppendToEnvFile(envPath string, values map[string]string) error {
 
 	// Append new variables
 	for _, k := range keys {
-		v := values[k]
-		if strings.ContainsAny(v, " \t\n\"'") {
-			v = fmt.Sprintf(`"%s"`, strings.ReplaceAll(v, `"`, `\"`))
-		}
-		fmt.Fprintf(file, "%s=%s\n", k, v)
+		fmt.Fprintf(file, "%s=%s\n", k, formatEnvValue(values[k]))
 	}
 
 	return nil
@@ -966,9 +1324,134 @@ func ParseReadmeForEnvVars(projectPath string) ([]ReadmeEnvConfig, error) {
 	// Parse code blocks and extract env vars
 	configs = extractEnvVarsFromReadme(contentStr, projectPath)
 
+	// Tables document variables more precisely than examples, so they win on conflicts
+	configs = mergeReadmeEnvConfigs(extractEnvVarsFromReadmeTables(contentStr, projectPath), configs)
+
 	return configs, nil
 }
 
+// readmeTableNamePattern matches an env var name in the first cell of a Markdown table row
+var readmeTableNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// extractEnvVarsFromReadmeTables parses Markdown tables such as
+// `| VAR_NAME | Description | Required |` for environment variable definitions
+func extractEnvVarsFromReadmeTables(content string, projectPath string) []ReadmeEnvConfig {
+	var configs []ReadmeEnvConfig
+	seen := make(map[string]bool)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		cells := splitTableRow(line)
+		if len(cells) < 2 || isTableSeparator(cells) {
+			continue
+		}
+		// The row above a separator is the table header
+		if i+1 < len(lines) && isTableSeparator(splitTableRow(lines[i+1])) {
+			continue
+		}
+
+		name := strings.Trim(cells[0], "`*")
+		if !readmeTableNamePattern.MatchString(name) || seen[name] || ignoredEnvVars[name] {
+			continue
+		}
+		seen[name] = true
+
+		config := ReadmeEnvConfig{
+			Name:        name,
+			Description: cells[1],
+			TargetDir:   determineTargetDir(name, "", projectPath),
+		}
+		if len(cells) > 2 {
+			config.Required, config.HasRequired = parseRequiredCell(cells[2])
+		}
+		configs = append(configs, config)
+	}
+
+	return configs
+}
+
+// parseRequiredCell interprets the Required column of a README table.
+// Negations win, so "Not required" and "optional (not required)" are optional.
+// ok is false when the cell says neither, e.g. it is empty or "-".
+func parseRequiredCell(cell string) (required bool, ok bool) {
+	words := strings.FieldsFunc(strings.ToLower(cell), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		switch word {
+		case "no", "not", "optional", "false":
+			return false, true
+		}
+	}
+	for _, word := range words {
+		switch word {
+		case "yes", "required", "true", "mandatory":
+			return true, true
+		}
+	}
+	if strings.ContainsAny(cell, "✓✔✅") {
+		return true, true
+	}
+	return false, false
+}
+
+// splitTableRow returns the trimmed cells of a Markdown table row, or nil if line is not one
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "|") {
+		return nil
+	}
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// isTableSeparator reports whether cells form a header separator row like `|---|:---:|`
+func isTableSeparator(cells []string) bool {
+	if len(cells) == 0 {
+		return false
+	}
+	for _, cell := range cells {
+		if cell == "" || strings.Trim(cell, "-:") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeReadmeEnvConfigs combines README sources, keeping preferred entries on conflicts.
+// Values and target directories missing from a preferred entry are taken from the other source.
+func mergeReadmeEnvConfigs(preferred []ReadmeEnvConfig, others []ReadmeEnvConfig) []ReadmeEnvConfig {
+	index := make(map[string]int, len(preferred))
+	merged := append([]ReadmeEnvConfig{}, preferred...)
+	for i, config := range merged {
+		index[config.Name] = i
+	}
+
+	for _, config := range others {
+		i, ok := index[config.Name]
+		if !ok {
+			merged = append(merged, config)
+			continue
+		}
+		if merged[i].Value == "" {
+			merged[i].Value = config.Value
+		}
+		if merged[i].TargetDir == "" {
+			merged[i].TargetDir = config.TargetDir
+		}
+		if merged[i].Description == "" {
+			merged[i].Description = config.Description
+		}
+	}
+
+	return merged
+}
+
 // extractEnvVarsFromReadme parses README content for environment variable definitions
 func extractEnvVarsFromReadme(content string, projectPath string) []ReadmeEnvConfig {
 	var configs []ReadmeEnvConfig
@@ -1247,11 +1730,25 @@ func CheckEnvStatusWithReadme(projectPath string, language string) (EnvStatus, e
 	// Group by target directories
 	status.EnvTargets = GroupEnvVarsByTarget(readmeConfigs, projectPath)
 
+	// A README table that says whether a variable is required overrides the heuristics
+	for i, v := range status.Required {
+		if config, ok := status.ReadmeDefaults[v.Name]; ok && config.HasRequired {
+			status.Required[i].Required = config.Required
+		}
+	}
+
 	// Update missing vars with defaults and target directories
 	for i, v := range status.Missing {
 		if config, ok := status.ReadmeDefaults[v.Name]; ok {
-			status.Missing[i].DefaultValue = config.Value
+			if config.HasRequired {
+				status.Missing[i].Required = config.Required
+			}
 			status.Missing[i].TargetDir = config.TargetDir
+			// README values take priority over example files and heuristics
+			if config.Value != "" {
+				status.Missing[i].DefaultValue = config.Value
+				status.Missing[i].Source = SourceReadme
+			}
 		}
 	}
 
@@ -1366,8 +1863,9 @@ type AutoProvisionResult struct {
 }
 
 // AutoProvisionEnvFiles automatically creates missing .env files with defaults from README
-// Returns information about what was created and which variables still need values
-func AutoProvisionEnvFiles(projectPath string, language string) (*AutoProvisionResult, error) {
+// Returns information about what was created and which variables still need values.
+// With dryRun set nothing is written; the result describes what would be created.
+func AutoProvisionEnvFiles(projectPath string, language string, dryRun bool) (*AutoProvisionResult, error) {
 	result := &AutoProvisionResult{
 		CreatedFiles:    []string{},
 		ProvisionedVars: make(map[string]string),
@@ -1444,6 +1942,13 @@ func AutoProvisionEnvFiles(projectPath string, language string) (*AutoProvisionR
 		_, existedBefore := os.Stat(envPath)
 		fileExisted := existedBefore == nil
 
+		if dryRun {
+			if !fileExisted {
+				result.CreatedFiles = append(result.CreatedFiles, displayPath)
+			}
+			continue
+		}
+
 		// Ensure directory exists
 		dir := filepath.Dir(envPath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
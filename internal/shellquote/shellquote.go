@@ -0,0 +1,19 @@
+// Package shellquote quotes strings for POSIX shells, for the scripts and
+// commands octo prints or writes for the user to run.
+package shellquote
+
+import "strings"
+
+// Quote wraps s in single quotes so a POSIX shell reads it literally
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Join quotes each value and joins them with spaces
+func Join(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = Quote(v)
+	}
+	return strings.Join(quoted, " ")
+}
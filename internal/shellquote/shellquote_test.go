@@ -0,0 +1,33 @@
+package shellquote
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"":            `''`,
+		"plain":       `'plain'`,
+		"two words":   `'two words'`,
+		"it's":        `'it'\''s'`,
+		"$HOME `id`":  "'$HOME `id`'",
+		"line\nbreak": "'line\nbreak'",
+	}
+	for in, want := range tests {
+		if got := Quote(in); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	values := []string{"a b", "it's", "$PATH", ""}
+	out, err := exec.Command("sh", "-c", "printf '%s|' "+Join(values)).Output()
+	if err != nil {
+		t.Skipf("sh not available: %v", err)
+	}
+	if want := "a b|it's|$PATH||"; string(out) != want {
+		t.Errorf("sh read Join(%q) as %q, want %q", values, out, want)
+	}
+}
@@ -2,8 +2,11 @@ package thermal
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -21,15 +24,18 @@ type Config struct {
 
 // HardwareInfo contains detected hardware information
 type HardwareInfo struct {
-	NumCPU         int
-	IsDarwin       bool
-	IsMacBookAir   bool
-	IsAppleSilicon bool
-	ModelName      string
+	NumCPU           int
+	IsDarwin         bool
+	IsMacBookAir     bool
+	IsAppleSilicon   bool
+	ModelName        string
+	CPUModel         string // CPU brand string, e.g. "Apple M1" or "Intel(R) Core(TM) i7-12700H"
+	PerformanceCores int    // Apple Silicon performance (Firestorm/Everest) cores, 0 if unknown
+	EfficiencyCores  int    // Apple Silicon efficiency (Icestorm/Sawtooth) cores, 0 if unknown
 }
 
 // DefaultBatchThreshold is the project count threshold for enabling batching
-const DefaultBatchThreshold = 5
+const DefaultBatchThreshold = 10
 
 // DefaultCoolDownMs is the default cool-down period between batches
 const DefaultCoolDownMs = 500
@@ -45,7 +51,11 @@ func DetectHardware() HardwareInfo {
 		info.ModelName = detectMacModel()
 		info.IsMacBookAir = strings.Contains(strings.ToLower(info.ModelName), "macbook air")
 		info.IsAppleSilicon = detectAppleSilicon()
+		if info.IsAppleSilicon {
+			info.PerformanceCores, info.EfficiencyCores = detectCoreTypes()
+		}
 	}
+	info.CPUModel = detectCPUModel()
 
 	return info
 }
@@ -76,6 +86,36 @@ func detectMacModel() string {
 	return strings.TrimSpace(string(output))
 }
 
+// detectCPUModel returns the CPU brand string (sysctl on macOS, /proc/cpuinfo on Linux)
+func detectCPUModel() string {
+	switch runtime.GOOS {
+	case "darwin":
+		output, err := exec.Command("sysctl", "-n", "machdep.cpu.brand_string").Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(output))
+	case "linux":
+		data, err := os.ReadFile("/proc/cpuinfo")
+		if err != nil {
+			return ""
+		}
+		return parseCPUInfoModel(string(data))
+	}
+	return ""
+}
+
+// parseCPUInfoModel returns the first "model name" entry of /proc/cpuinfo
+func parseCPUInfoModel(cpuinfo string) string {
+	for _, line := range strings.Split(cpuinfo, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found && strings.TrimSpace(key) == "model name" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
 // detectAppleSilicon checks if the Mac has Apple Silicon
 func detectAppleSilicon() bool {
 	// Check architecture
@@ -93,6 +133,32 @@ func detectAppleSilicon() bool {
 	return strings.Contains(brand, "apple")
 }
 
+// detectCoreTypes returns the number of performance and efficiency cores of an Apple Silicon Mac.
+// perflevel0 is the fastest core type, perflevel1 the efficiency cores.
+func detectCoreTypes() (performance int, efficiency int) {
+	return sysctlInt("hw.perflevel0.logicalcpu"), sysctlInt("hw.perflevel1.logicalcpu")
+}
+
+// sysctlInt reads an integer sysctl value, returning 0 if it is unavailable
+func sysctlInt(name string) int {
+	output, err := exec.Command("sysctl", "-n", name).Output()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(output)))
+	return n
+}
+
+// CoolConcurrency returns the worker count for the cool thermal mode. On Apple Silicon
+// work is limited to the number of efficiency cores to keep the fans quiet; elsewhere
+// half the cores are used.
+func CoolConcurrency(hw HardwareInfo) int {
+	if hw.EfficiencyCores > 0 {
+		return hw.EfficiencyCores
+	}
+	return hw.NumCPU / 2
+}
+
 // GetOptimalConcurrency returns the optimal concurrency level based on hardware
 func GetOptimalConcurrency(hw HardwareInfo, configConcurrency int) int {
 	// If explicitly configured, use that value
@@ -137,10 +203,7 @@ func GetOptimalBatchSize(hw HardwareInfo, projectCount int, configBatchSize int)
 	// Base batch size on hardware
 	batchSize := 3 // Conservative default
 
-	if hw.IsMacBookAir {
-		// Very conservative for passive cooling
-		batchSize = 2
-	} else if hw.IsDarwin && hw.IsAppleSilicon {
+	if hw.IsDarwin && hw.IsAppleSilicon {
 		// Moderate for active cooling Apple Silicon
 		batchSize = 4
 	} else if hw.NumCPU >= 8 {
@@ -148,6 +211,20 @@ func GetOptimalBatchSize(hw HardwareInfo, projectCount int, configBatchSize int)
 		batchSize = 5
 	}
 
+	// Halve for passive cooling
+	if hw.IsMacBookAir {
+		batchSize /= 2
+	}
+
+	// Never batch more than the CPUs can reasonably handle
+	if hw.NumCPU > 0 && batchSize > hw.NumCPU*4 {
+		batchSize = hw.NumCPU * 4
+	}
+
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
 	return batchSize
 }
 
@@ -375,17 +452,136 @@ func GetThermalStatus(hw HardwareInfo) ThermalStatus {
 	return status
 }
 
+// appleChipDetails describes Apple Silicon chips by their core designs and
+// the largest GPU configuration each chip ships with
+var appleChipDetails = map[string]string{
+	"Apple M1":       "Firestorm/Icestorm",
+	"Apple M1 Pro":   "Firestorm/Icestorm, up to 16 GPU cores",
+	"Apple M1 Max":   "Firestorm/Icestorm, up to 32 GPU cores",
+	"Apple M1 Ultra": "Firestorm/Icestorm, up to 64 GPU cores",
+	"Apple M2":       "Avalanche/Blizzard",
+	"Apple M2 Pro":   "Avalanche/Blizzard, up to 19 GPU cores",
+	"Apple M2 Max":   "Avalanche/Blizzard, up to 38 GPU cores",
+	"Apple M2 Ultra": "Avalanche/Blizzard, up to 76 GPU cores",
+	"Apple M3":       "Everest/Sawtooth",
+	"Apple M3 Pro":   "Everest/Sawtooth, up to 18 GPU cores",
+	"Apple M3 Max":   "Everest/Sawtooth, up to 40 GPU cores",
+	"Apple M3 Ultra": "Everest/Sawtooth, up to 80 GPU cores",
+	"Apple M4":       "up to 10 GPU cores",
+	"Apple M4 Pro":   "up to 20 GPU cores",
+	"Apple M4 Max":   "up to 40 GPU cores",
+}
+
+// intelGenerations maps Intel Core generations (the leading digits of the model number) to code names
+var intelGenerations = map[int]string{
+	6:  "Skylake",
+	7:  "Kaby Lake",
+	8:  "Coffee Lake",
+	9:  "Coffee Lake Refresh",
+	10: "Comet Lake",
+	11: "Tiger Lake/Rocket Lake",
+	12: "Alder Lake",
+	13: "Raptor Lake",
+	14: "Raptor Lake Refresh",
+}
+
+// intelModelGenerations overrides intelGenerations for 8th generation laptop chips,
+// which span several code names
+var intelModelGenerations = map[string]string{
+	"8130U": "Kaby Lake R",
+	"8250U": "Kaby Lake R",
+	"8350U": "Kaby Lake R",
+	"8550U": "Kaby Lake R",
+	"8650U": "Kaby Lake R",
+	"8145U": "Whiskey Lake",
+	"8265U": "Whiskey Lake",
+	"8365U": "Whiskey Lake",
+	"8565U": "Whiskey Lake",
+	"8665U": "Whiskey Lake",
+	"8200Y": "Amber Lake",
+	"8210Y": "Amber Lake",
+	"8310Y": "Amber Lake",
+	"8500Y": "Amber Lake",
+}
+
+// intelCorePattern matches Intel Core model numbers such as "i7-12700H" or "Ultra 7 155H"
+var intelCorePattern = regexp.MustCompile(`\b(?:i[3579]-(\d{4,5})|Ultra [579] (\d)\d\d)([A-Z]*)`)
+
+// cpuBrandNoise is removed from CPU brand strings before they are displayed
+var cpuBrandNoise = regexp.MustCompile(`\(R\)|\(TM\)|\b\d+th Gen\b|\bCPU\b|@.*$`)
+
+// DescribeCPU returns a readable CPU name with its chip generation, e.g.
+// "Apple M1 (Firestorm/Icestorm)" or "Intel Core i7-12700H (Alder Lake)".
+// Unknown CPUs are returned with trademark noise removed.
+func DescribeCPU(model string) string {
+	name := strings.Join(strings.Fields(cpuBrandNoise.ReplaceAllString(model, "")), " ")
+	if name == "" {
+		return ""
+	}
+
+	if details, ok := appleChipDetails[name]; ok {
+		return fmt.Sprintf("%s (%s)", name, details)
+	}
+
+	if generation := intelGeneration(name); generation != "" {
+		return fmt.Sprintf("%s (%s)", name, generation)
+	}
+	return name
+}
+
+// intelGeneration returns the code name of an Intel Core CPU, or "" if unknown
+func intelGeneration(name string) string {
+	matches := intelCorePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return ""
+	}
+
+	// Core Ultra: series 1 is Meteor Lake, series 2 is Lunar Lake (V suffix) or Arrow Lake
+	switch matches[2] {
+	case "1":
+		return "Meteor Lake"
+	case "2":
+		if matches[3] == "V" {
+			return "Lunar Lake"
+		}
+		return "Arrow Lake"
+	}
+
+	number := matches[1]
+	if generation, ok := intelModelGenerations[number+matches[3]]; ok {
+		return generation
+	}
+
+	// Five-digit model numbers (12700) carry a two-digit generation, four-digit ones (8550) a single digit
+	genDigits := 1
+	if len(number) == 5 {
+		genDigits = 2
+	}
+	generation, _ := strconv.Atoi(number[:genDigits])
+	return intelGenerations[generation]
+}
+
 // FormatHardwareInfo returns a human-readable hardware description
 func FormatHardwareInfo(hw HardwareInfo) string {
 	var parts []string
 
-	parts = append(parts, fmt.Sprintf("%d cores", hw.NumCPU))
+	if hw.PerformanceCores > 0 && hw.EfficiencyCores > 0 {
+		parts = append(parts, fmt.Sprintf("%d cores (%dP + %dE)", hw.NumCPU, hw.PerformanceCores, hw.EfficiencyCores))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d cores", hw.NumCPU))
+	}
+
+	cpu := DescribeCPU(hw.CPUModel)
+	if cpu != "" {
+		parts = append(parts, cpu)
+	}
 
 	if hw.IsDarwin {
 		if hw.ModelName != "" {
 			parts = append(parts, hw.ModelName)
 		}
-		if hw.IsAppleSilicon {
+		// The chip name already says so for Apple CPUs
+		if hw.IsAppleSilicon && !strings.HasPrefix(cpu, "Apple") {
 			parts = append(parts, "Apple Silicon")
 		}
 	} else {
@@ -79,31 +79,38 @@ func TestGetOptimalBatchSize(t *testing.T) {
 		{
 			name:            "configured batch size takes precedence",
 			hw:              HardwareInfo{NumCPU: 8, IsMacBookAir: true},
-			projectCount:    10,
+			projectCount:    20,
 			configBatchSize: 5,
 			want:            5,
 		},
 		{
 			name:            "below threshold returns project count",
 			hw:              HardwareInfo{NumCPU: 8},
-			projectCount:    3,
+			projectCount:    8,
 			configBatchSize: 0,
-			want:            3,
+			want:            8,
 		},
 		{
 			name:            "MacBook Air uses batch size 2",
 			hw:              HardwareInfo{NumCPU: 8, IsMacBookAir: true},
-			projectCount:    10,
+			projectCount:    20,
 			configBatchSize: 0,
 			want:            2,
 		},
 		{
 			name:            "Apple Silicon uses batch size 4",
 			hw:              HardwareInfo{NumCPU: 10, IsDarwin: true, IsAppleSilicon: true},
-			projectCount:    10,
+			projectCount:    20,
 			configBatchSize: 0,
 			want:            4,
 		},
+		{
+			name:            "Apple Silicon MacBook Air halves batch size",
+			hw:              HardwareInfo{NumCPU: 8, IsDarwin: true, IsAppleSilicon: true, IsMacBookAir: true},
+			projectCount:    20,
+			configBatchSize: 0,
+			want:            2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -214,3 +221,83 @@ func TestFormatHardwareInfo(t *testing.T) {
 		t.Errorf("FormatHardwareInfo() = %q, want %q", got, want)
 	}
 }
+
+func TestFormatHardwareInfoWithCPUModel(t *testing.T) {
+	hw := HardwareInfo{
+		NumCPU:         8,
+		IsDarwin:       true,
+		ModelName:      "MacBookAir10,1",
+		IsAppleSilicon: true,
+		CPUModel:       "Apple M1",
+	}
+	got := FormatHardwareInfo(hw)
+	want := "8 cores, Apple M1 (Firestorm/Icestorm), MacBookAir10,1"
+	if got != want {
+		t.Errorf("FormatHardwareInfo() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatHardwareInfoWithCoreTypes(t *testing.T) {
+	hw := HardwareInfo{
+		NumCPU:           8,
+		IsDarwin:         true,
+		ModelName:        "MacBookAir10,1",
+		IsAppleSilicon:   true,
+		PerformanceCores: 4,
+		EfficiencyCores:  4,
+	}
+	got := FormatHardwareInfo(hw)
+	want := "8 cores (4P + 4E), MacBookAir10,1, Apple Silicon"
+	if got != want {
+		t.Errorf("FormatHardwareInfo() = %q, want %q", got, want)
+	}
+}
+
+func TestCoolConcurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		hw   HardwareInfo
+		want int
+	}{
+		{"Apple Silicon uses efficiency cores", HardwareInfo{NumCPU: 12, IsAppleSilicon: true, PerformanceCores: 8, EfficiencyCores: 4}, 4},
+		{"unknown core types use half the cores", HardwareInfo{NumCPU: 12}, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoolConcurrency(tt.hw); got != tt.want {
+				t.Errorf("CoolConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeCPU(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{"Apple M1", "Apple M1 (Firestorm/Icestorm)"},
+		{"Apple M3 Pro", "Apple M3 Pro (Everest/Sawtooth, up to 18 GPU cores)"},
+		{"12th Gen Intel(R) Core(TM) i7-12700H", "Intel Core i7-12700H (Alder Lake)"},
+		{"Intel(R) Core(TM) i5-8250U CPU @ 1.60GHz", "Intel Core i5-8250U (Kaby Lake R)"},
+		{"Intel(R) Core(TM) i7-8565U CPU @ 1.80GHz", "Intel Core i7-8565U (Whiskey Lake)"},
+		{"Intel(R) Core(TM) i7-8750H CPU @ 2.20GHz", "Intel Core i7-8750H (Coffee Lake)"},
+		{"Intel(R) Core(TM) Ultra 7 155H", "Intel Core Ultra 7 155H (Meteor Lake)"},
+		{"AMD Ryzen 9 7950X 16-Core Processor", "AMD Ryzen 9 7950X 16-Core Processor"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := DescribeCPU(tt.model); got != tt.want {
+			t.Errorf("DescribeCPU(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestParseCPUInfoModel(t *testing.T) {
+	cpuinfo := "processor\t: 0\nvendor_id\t: GenuineIntel\nmodel name\t: Intel(R) Core(TM) i7-12700H\n"
+	if got := parseCPUInfoModel(cpuinfo); got != "Intel(R) Core(TM) i7-12700H" {
+		t.Errorf("parseCPUInfoModel() = %q", got)
+	}
+}
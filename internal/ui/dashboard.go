@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"runtime"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -57,21 +59,45 @@ type Project struct {
 	Logs        []string
 	Error       error
 	StartTime   time.Time
-	Port        int       // Port the project is running on (for URL display)
-	URL         string    // Full URL to access the project
-	Cmd         *exec.Cmd // Running command for graceful shutdown
-	urlPriority int       // Priority score for URL (higher = more likely to be frontend)
+	Port        int              // Port the project is running on (for URL display)
+	URL         string           // Full URL to access the project
+	Cmd         *exec.Cmd        // Running command for graceful shutdown
+	urlPriority int              // Priority score for URL (higher = more likely to be frontend)
+	urlTemplate string           // Custom URL format from --url-template (empty = the URL the app reports)
+	urlPath     string           // Path of the URL the app logged, for the {path} token
+	onURL       func(url string) // Called when a URL is detected in the logs
+	RunCmd      string           // Command the project was started with, re-run by the Restart key
+	restart     bool             // Set while a restart requested from the dashboard is pending
+	maxLogLines int              // Lines kept in Logs before the oldest are dropped
+	logBytes    int              // Total size of Logs, for the dashboard-wide byte budget
 	mu          sync.RWMutex
 }
 
+// DefaultMaxLogLines is how many log lines a project keeps when no limit is configured
+const DefaultMaxLogLines = 1000
+
 // NewProject creates a new project entry
 func NewProject(name, path string) *Project {
 	return &Project{
-		Name:   name,
-		Path:   path,
-		Phase:  PhaseIdle,
-		Status: StatusPending,
-		Logs:   make([]string, 0, 1000),
+		Name:        name,
+		Path:        path,
+		Phase:       PhaseIdle,
+		Status:      StatusPending,
+		Logs:        make([]string, 0, DefaultMaxLogLines),
+		maxLogLines: DefaultMaxLogLines,
+	}
+}
+
+// SetMaxLogLines changes how many log lines are kept; n <= 0 restores the default (thread-safe)
+func (p *Project) SetMaxLogLines(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n <= 0 {
+		n = DefaultMaxLogLines
+	}
+	p.maxLogLines = n
+	for len(p.Logs) > n {
+		p.dropOldestLogLocked()
 	}
 }
 
@@ -80,11 +106,16 @@ func NewProject(name, path string) *Project {
 func (p *Project) AppendLog(line string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	// Keep last 1000 lines
-	if len(p.Logs) >= 1000 {
-		p.Logs = p.Logs[1:]
+	// Keep the last maxLogLines lines
+	maxLines := p.maxLogLines
+	if maxLines <= 0 {
+		maxLines = DefaultMaxLogLines
+	}
+	for len(p.Logs) >= maxLines {
+		p.dropOldestLogLocked()
 	}
 	p.Logs = append(p.Logs, line)
+	p.logBytes += len(line)
 	
 	// Auto-detect URL from common dev server patterns
 	// Uses intelligent priority scoring to prefer frontend URLs over backend APIs
@@ -95,6 +126,7 @@ func (p *Project) AppendLog(line string) {
 type URLCandidate struct {
 	URL      string
 	Port     int
+	Path     string // Path after host:port in the logged URL (e.g. "/app/"), if any
 	Priority int // Higher = more likely to be the frontend the user wants
 	Source   string
 }
@@ -116,9 +148,14 @@ func (p *Project) detectURLFromLog(line string) {
 	// Only replace if new candidate has higher or equal priority
 	// Equal priority allows later URLs to override (e.g., when frontend starts after backend)
 	if candidate.Priority >= currentPriority {
-		p.URL = candidate.URL
+		p.urlPath = candidate.Path
+		p.URL = p.resolveURL(candidate.URL, candidate.Port)
 		p.Port = candidate.Port
 		p.urlPriority = candidate.Priority
+		if p.onURL != nil {
+			// Run outside p.mu so the handler may use the project's getters
+			go p.onURL(p.URL)
+		}
 	}
 }
 
@@ -127,7 +164,7 @@ func (p *Project) extractURLCandidate(line string) *URLCandidate {
 	lowerLine := strings.ToLower(line)
 	
 	// Pattern to extract any localhost URL
-	urlPattern := regexp.MustCompile(`(https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0):(\d+))`)
+	urlPattern := regexp.MustCompile(`(https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0):(\d+))(/[^\s"'<>]*)?`)
 	matches := urlPattern.FindStringSubmatch(line)
 	if len(matches) < 3 {
 		return nil
@@ -217,6 +254,7 @@ func (p *Project) extractURLCandidate(line string) *URLCandidate {
 	return &URLCandidate{
 		URL:      url,
 		Port:     port,
+		Path:     matches[3],
 		Priority: priority,
 		Source:   line,
 	}
@@ -254,10 +292,46 @@ func (p *Project) SetPort(port int) {
 	defer p.mu.Unlock()
 	p.Port = port
 	if port > 0 {
-		p.URL = fmt.Sprintf("http://localhost:%d", port)
+		p.URL = p.resolveURL(fmt.Sprintf("http://localhost:%d", port), port)
 	}
 }
 
+// SetURLTemplate sets a custom URL format (see ExpandURLTemplate) used instead of
+// the URL the app reports (thread-safe)
+func (p *Project) SetURLTemplate(template string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.urlTemplate = template
+	if template != "" && p.Port > 0 {
+		p.URL = p.resolveURL(p.URL, p.Port)
+	}
+}
+
+// resolveURL applies the project's URL template, if any, to a detected URL.
+// The caller must hold p.mu.
+func (p *Project) resolveURL(detected string, port int) string {
+	if p.urlTemplate == "" {
+		return detected
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	return ExpandURLTemplate(p.urlTemplate, p.Name, host, port, p.urlPath)
+}
+
+// ExpandURLTemplate replaces the {project}, {port}, {host} and {path} tokens in a
+// --url-template such as "https://{project}.local:{port}{path}".
+// {host} is the machine's hostname and {path} the path the app logged with its URL.
+func ExpandURLTemplate(template, project, host string, port int, path string) string {
+	return strings.NewReplacer(
+		"{project}", project,
+		"{port}", strconv.Itoa(port),
+		"{host}", host,
+		"{path}", path,
+	).Replace(template)
+}
+
 // SetURL sets the URL for the project (thread-safe)
 func (p *Project) SetURL(url string) {
 	p.mu.Lock()
@@ -265,6 +339,13 @@ func (p *Project) SetURL(url string) {
 	p.URL = url
 }
 
+// OnURLDetected registers fn to be called whenever a URL is detected in the logs (thread-safe)
+func (p *Project) OnURLDetected(fn func(url string)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onURL = fn
+}
+
 // GetURL returns the project URL (thread-safe)
 func (p *Project) GetURL() string {
 	p.mu.RLock()
@@ -286,6 +367,78 @@ func (p *Project) GetCmd() *exec.Cmd {
 	return p.Cmd
 }
 
+// SetRunCmd records the command the project was started with (thread-safe)
+func (p *Project) SetRunCmd(command string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.RunCmd = command
+}
+
+// GetRunCmd returns the command the project was started with (thread-safe)
+func (p *Project) GetRunCmd() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.RunCmd
+}
+
+// ClearLogs empties the project's log buffer (thread-safe)
+func (p *Project) ClearLogs() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Logs = p.Logs[:0]
+	p.logBytes = 0
+}
+
+// LogBytes returns the total size of the buffered log lines (thread-safe)
+func (p *Project) LogBytes() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.logBytes
+}
+
+// dropOldestLog removes the oldest log line and returns its size (thread-safe)
+func (p *Project) dropOldestLog() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.dropOldestLogLocked()
+}
+
+// dropOldestLogLocked removes the oldest log line; the caller must hold p.mu
+func (p *Project) dropOldestLogLocked() int {
+	if len(p.Logs) == 0 {
+		return 0
+	}
+	size := len(p.Logs[0])
+	p.Logs = p.Logs[1:]
+	p.logBytes -= size
+	return size
+}
+
+// RequestRestart stops the running process and flags it to be started again with RunCmd.
+// Whoever waits on the process relaunches it (see TakeRestartRequest). It returns false
+// if the project is not running a command that can be restarted.
+func (p *Project) RequestRestart() bool {
+	p.mu.Lock()
+	if p.Cmd == nil || p.RunCmd == "" {
+		p.mu.Unlock()
+		return false
+	}
+	p.restart = true
+	p.mu.Unlock()
+
+	p.GracefulStop()
+	return true
+}
+
+// TakeRestartRequest reports whether a restart was requested, clearing the request (thread-safe)
+func (p *Project) TakeRestartRequest() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	requested := p.restart
+	p.restart = false
+	return requested
+}
+
 // GracefulStop attempts to stop the project's process immediately
 // Sends SIGINT to the process group, then SIGKILL if needed
 func (p *Project) GracefulStop() error {
@@ -379,6 +532,17 @@ type DashboardModel struct {
 	compactMode     bool // Toggle between dashboard and compact mode (Tab key)
 	logsFocused     bool // Whether logs are focused in compact mode (enables scrolling)
 	
+	// Log filter (focused view)
+	filterInput   textinput.Model
+	filtering     bool           // Whether the filter input is open
+	filter        *regexp.Regexp // Active filter, nil shows every line
+	filterErr     error          // Set while the input is not a valid regex
+	filterMatched int            // Lines shown by the active filter
+	filterTotal   int            // Lines in the focused project's log
+	
+	// Combined size of all project logs before the oldest lines are pruned (0 = unlimited)
+	maxTotalLogBytes int
+	
 	// Channels for updates
 	updateChan chan tea.Msg
 	
@@ -391,15 +555,20 @@ type DashboardModel struct {
 
 // keyMap defines the key bindings for the dashboard
 type keyMap struct {
-	Up         key.Binding
-	Down       key.Binding
-	Enter      key.Binding
-	Escape     key.Binding
-	Help       key.Binding
-	Quit       key.Binding
-	StopAll    key.Binding
-	ToggleMode key.Binding
-	OpenURL    key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Enter       key.Binding
+	Escape      key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+	StopAll     key.Binding
+	Restart     key.Binding
+	ToggleMode  key.Binding
+	OpenURL     key.Binding
+	Snapshot    key.Binding
+	Filter      key.Binding
+	ClearFilter key.Binding
+	ClearLogs   key.Binding
 }
 
 func defaultKeyMap() keyMap {
@@ -432,6 +601,10 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("ctrl+x"),
 			key.WithHelp("ctrl+x", "stop all"),
 		),
+		Restart: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "restart"),
+		),
 		ToggleMode: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "toggle view"),
@@ -440,6 +613,22 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "open in browser"),
 		),
+		Snapshot: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save HTML snapshot"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter logs"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("ctrl+l"),
+			key.WithHelp("ctrl+l", "clear filter"),
+		),
+		ClearLogs: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("ctrl+k", "clear logs"),
+		),
 	}
 }
 
@@ -479,6 +668,7 @@ type Styles struct {
 	LogViewport lipgloss.Style
 	LogLine     lipgloss.Style
 	LogError    lipgloss.Style
+	LogMatch    lipgloss.Style
 	
 	// Help styles
 	Help     lipgloss.Style
@@ -488,12 +678,17 @@ type Styles struct {
 
 // DefaultStyles returns the default color scheme
 func DefaultStyles() *Styles {
-	subtle := lipgloss.AdaptiveColor{Light: "#666", Dark: "#999"}
-	highlight := lipgloss.AdaptiveColor{Light: "#7D56F4", Dark: "#AD8EE6"}
-	success := lipgloss.AdaptiveColor{Light: "#00AA00", Dark: "#00FF00"}
-	warning := lipgloss.AdaptiveColor{Light: "#AAAA00", Dark: "#FFFF00"}
-	errorColor := lipgloss.AdaptiveColor{Light: "#AA0000", Dark: "#FF0000"}
-	info := lipgloss.AdaptiveColor{Light: "#0066CC", Dark: "#00AAFF"}
+	return newStyles(defaultPalette)
+}
+
+// newStyles builds the dashboard styles from a theme's colors
+func newStyles(p palette) *Styles {
+	subtle := p.subtle
+	highlight := p.highlight
+	success := p.success
+	warning := p.warning
+	errorColor := p.errorColor
+	info := p.info
 	
 	return &Styles{
 		App: lipgloss.NewStyle().
@@ -526,7 +721,7 @@ func DefaultStyles() *Styles {
 		
 		ProjectSelected: lipgloss.NewStyle().
 			Padding(0, 1).
-			Background(lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#333333"}).
+			Background(p.selected).
 			Bold(true),
 		
 		ProjectFocused: lipgloss.NewStyle().
@@ -556,10 +751,10 @@ func DefaultStyles() *Styles {
 			Foreground(subtle),
 		
 		PhaseSetup: lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#9933FF", Dark: "#CC99FF"}),
+			Foreground(p.setup),
 		
 		PhaseBuild: lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#FF9900", Dark: "#FFCC00"}),
+			Foreground(p.build),
 		
 		PhaseRun: lipgloss.NewStyle().
 			Foreground(info),
@@ -589,6 +784,11 @@ func DefaultStyles() *Styles {
 		LogError: lipgloss.NewStyle().
 			Foreground(errorColor),
 		
+		LogMatch: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(warning).
+			Bold(true),
+		
 		Help: lipgloss.NewStyle().
 			Foreground(subtle),
 		
@@ -615,6 +815,12 @@ type logMsg struct {
 }
 type quitMsg struct{}
 
+// shutdownMsg starts the shutdown countdown; shutdownTickMsg advances it each second
+type shutdownMsg struct{}
+type shutdownTickMsg struct {
+	remaining time.Duration
+}
+
 // NewDashboard creates a new dashboard model
 func NewDashboard(projects []*Project, maxConcurrency int) *DashboardModel {
 	vp := viewport.New(80, 20)
@@ -635,6 +841,7 @@ func NewDashboard(projects []*Project, maxConcurrency int) *DashboardModel {
 		compactViewport: cvp,
 		keys:            defaultKeyMap(),
 		styles:          DefaultStyles(),
+		filterInput:     newFilterInput(),
 		updateChan:      make(chan tea.Msg, 100),
 		compactMode:     true, // Default to compact (normal scrolling) view
 		logsFocused:     true, // Logs are focused by default for scrolling
@@ -667,6 +874,11 @@ func (m *DashboardModel) listenForUpdates() tea.Cmd {
 func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	
+	// While the filter input is open it receives every key except ctrl+c
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.filtering && keyMsg.String() != "ctrl+c" {
+		return m, m.updateFilterInput(keyMsg)
+	}
+	
 	// Handle quit FIRST - before anything else can consume the key
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		if key.Matches(keyMsg, m.keys.Quit) {
@@ -715,6 +927,33 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			
+		case key.Matches(msg, m.keys.Restart):
+			if m.selectedIndex >= 0 && m.selectedIndex < len(m.projects) {
+				cmds = append(cmds, m.restartProject(m.projects[m.selectedIndex]))
+			}
+			
+		case key.Matches(msg, m.keys.Snapshot):
+			// Save the current state as a shareable HTML file
+			path, err := m.SaveSnapshot()
+			if err != nil {
+				m.broadcastLog(fmt.Sprintf("⚠️  Failed to save snapshot: %v", err))
+			} else {
+				m.broadcastLog(fmt.Sprintf("📸 Snapshot saved to %s", path))
+			}
+			
+		case key.Matches(msg, m.keys.Filter):
+			if !m.compactMode && m.focusedIndex >= 0 {
+				cmds = append(cmds, m.openFilter())
+			}
+			
+		case key.Matches(msg, m.keys.ClearLogs) && !m.compactMode && m.focusedIndex >= 0 && m.focusedIndex < len(m.projects):
+			// Clear the focused project's logs
+			m.projects[m.focusedIndex].ClearLogs()
+			m.updateViewportContent()
+			
+		case key.Matches(msg, m.keys.ClearFilter):
+			m.clearFilter()
+			
 		case key.Matches(msg, m.keys.Up):
 			if m.compactMode && m.logsFocused {
 				// Scroll compact viewport up
@@ -761,6 +1000,9 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Escape):
 			if m.compactMode && m.logsFocused {
 				m.logsFocused = false
+			} else if m.focusedIndex >= 0 && m.filter != nil {
+				// First esc clears the filter, the next one leaves the focused view
+				m.clearFilter()
 			} else if m.focusedIndex >= 0 {
 				m.focusedIndex = -1
 			}
@@ -821,6 +1063,7 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case logMsg:
 		if msg.index >= 0 && msg.index < len(m.projects) {
 			m.projects[msg.index].AppendLog(msg.line)
+			m.pruneLogs()
 			if m.focusedIndex == msg.index {
 				m.updateViewportContent()
 			}
@@ -830,6 +1073,16 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		cmds = append(cmds, m.listenForUpdates())
 		
+	case shutdownMsg:
+		m.broadcastLog(shutdownMessage(gracefulShutdownTimeout))
+		cmds = append(cmds, shutdownTickCmd(gracefulShutdownTimeout), m.listenForUpdates())
+
+	case shutdownTickMsg:
+		if msg.remaining > 0 {
+			m.broadcastLog(shutdownMessage(msg.remaining))
+			cmds = append(cmds, shutdownTickCmd(msg.remaining))
+		}
+
 	case quitMsg:
 		m.quitting = true
 		return m, tea.Quit
@@ -838,6 +1091,51 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// SetMaxTotalLogBytes caps the combined size of all project logs; 0 disables the cap
+func (m *DashboardModel) SetMaxTotalLogBytes(n int) {
+	m.maxTotalLogBytes = n
+	m.pruneLogs()
+}
+
+// pruneLogs drops the oldest lines of the largest project logs until the combined
+// size fits within maxTotalLogBytes
+func (m *DashboardModel) pruneLogs() {
+	if m.maxTotalLogBytes <= 0 {
+		return
+	}
+
+	total := 0
+	for _, p := range m.projects {
+		total += p.LogBytes()
+	}
+	for total > m.maxTotalLogBytes {
+		var largest *Project
+		largestBytes := 0
+		for _, p := range m.projects {
+			if b := p.LogBytes(); b > largestBytes {
+				largest, largestBytes = p, b
+			}
+		}
+		if largest == nil {
+			return
+		}
+		total -= largest.dropOldestLog()
+	}
+}
+
+// restartProject stops the project in the background so the key press doesn't block the UI;
+// the process is relaunched once it has exited
+func (m *DashboardModel) restartProject(p *Project) tea.Cmd {
+	if p.GetCmd() == nil || p.GetRunCmd() == "" {
+		m.broadcastLog(fmt.Sprintf("⚠️  %s is not running and cannot be restarted", p.Name))
+		return nil
+	}
+	return func() tea.Msg {
+		p.RequestRestart()
+		return nil
+	}
+}
+
 // openInBrowser opens a URL in the default browser
 func (m *DashboardModel) openInBrowser(url string) {
 	var cmd *exec.Cmd
@@ -869,6 +1167,9 @@ func (m *DashboardModel) updateViewportContent() {
 	}
 	
 	logs := m.projects[m.focusedIndex].GetLogs()
+	m.filterTotal = len(logs)
+	logs = m.filterLogs(logs)
+	m.filterMatched = len(logs)
 	
 	// Check if user is at the bottom before updating content
 	atBottom := m.viewport.AtBottom()
@@ -1085,9 +1386,12 @@ func (m *DashboardModel) renderProjectItem(index int, p *Project, width int) str
 
 // renderPhase renders a phase indicator
 func (m *DashboardModel) renderPhase(phase Phase) string {
-	var style lipgloss.Style
-	var icon string
-	
+	style, icon := m.phaseAppearance(phase)
+	return style.Render(fmt.Sprintf("%s %-6s", icon, phase))
+}
+
+// phaseAppearance returns the style and icon used to display a phase
+func (m *DashboardModel) phaseAppearance(phase Phase) (style lipgloss.Style, icon string) {
 	switch phase {
 	case PhaseSetup:
 		style = m.styles.PhaseSetup
@@ -1106,14 +1410,17 @@ func (m *DashboardModel) renderPhase(phase Phase) string {
 		icon = "⏸️"
 	}
 	
-	return style.Render(fmt.Sprintf("%s %-6s", icon, phase))
+	return style, icon
 }
 
 // renderStatus renders a status indicator
 func (m *DashboardModel) renderStatus(status Status) string {
-	var style lipgloss.Style
-	var icon string
-	
+	style, icon := m.statusAppearance(status)
+	return style.Render(fmt.Sprintf("%s %s", icon, status))
+}
+
+// statusAppearance returns the style and icon used to display a status
+func (m *DashboardModel) statusAppearance(status Status) (style lipgloss.Style, icon string) {
 	switch status {
 	case StatusRunning:
 		style = m.styles.StatusRunning
@@ -1132,7 +1439,7 @@ func (m *DashboardModel) renderStatus(status Status) string {
 		icon = "◌"
 	}
 	
-	return style.Render(fmt.Sprintf("%s %s", icon, status))
+	return style, icon
 }
 
 // renderConcurrencyMonitor renders the concurrency monitor
@@ -1222,6 +1529,11 @@ func (m *DashboardModel) renderFocusedView() string {
 	b.WriteString(info)
 	b.WriteString("\n\n")
 	
+	if bar := m.renderFilterBar(); bar != "" {
+		b.WriteString(bar)
+		b.WriteString("\n")
+	}
+	
 	// Log viewport
 	viewportWidth := m.width - 6
 	if viewportWidth < 60 {
@@ -1343,10 +1655,17 @@ func (m *DashboardModel) renderFooter() string {
 		modeIndicator = "📋 Compact"
 	}
 	
-	if m.focusedIndex >= 0 {
-		help = fmt.Sprintf("%s • %s scroll • %s back • %s quit",
+	if m.filtering {
+		help = fmt.Sprintf("%s • %s apply • %s clear",
+			modeIndicator,
+			m.styles.HelpKey.Render("enter"),
+			m.styles.HelpKey.Render("esc"))
+	} else if m.focusedIndex >= 0 {
+		help = fmt.Sprintf("%s • %s scroll • %s filter • %s clear logs • %s back • %s quit",
 			modeIndicator,
 			m.styles.HelpKey.Render("↑↓/jk"),
+			m.styles.HelpKey.Render("/"),
+			m.styles.HelpKey.Render("ctrl+k"),
 			m.styles.HelpKey.Render("esc/enter"),
 			m.styles.HelpKey.Render("q"))
 	} else {
@@ -1360,18 +1679,22 @@ func (m *DashboardModel) renderFooter() string {
 		}
 		
 		if hasURL {
-			help = fmt.Sprintf("%s • %s nav • %s focus • %s open • %s view • %s quit",
+			help = fmt.Sprintf("%s • %s nav • %s focus • %s open • %s restart • %s snapshot • %s view • %s quit",
 				modeIndicator,
 				m.styles.HelpKey.Render("↑↓"),
 				m.styles.HelpKey.Render("enter"),
 				m.styles.HelpKey.Render("o"),
+				m.styles.HelpKey.Render("ctrl+r"),
+				m.styles.HelpKey.Render("s"),
 				m.styles.HelpKey.Render("tab"),
 				m.styles.HelpKey.Render("q"))
 		} else {
-			help = fmt.Sprintf("%s • %s nav • %s focus • %s view • %s quit",
+			help = fmt.Sprintf("%s • %s nav • %s focus • %s restart • %s snapshot • %s view • %s quit",
 				modeIndicator,
 				m.styles.HelpKey.Render("↑↓"),
 				m.styles.HelpKey.Render("enter"),
+				m.styles.HelpKey.Render("ctrl+r"),
+				m.styles.HelpKey.Render("s"),
 				m.styles.HelpKey.Render("tab"),
 				m.styles.HelpKey.Render("q"))
 		}
@@ -1413,6 +1736,29 @@ func (m *DashboardModel) SendQuit() {
 	}
 }
 
+// SendShutdown starts the shutdown countdown shown in every project's log panel
+func (m *DashboardModel) SendShutdown() {
+	select {
+	case m.updateChan <- shutdownMsg{}:
+	default:
+	}
+}
+
+// gracefulShutdownTimeout is how long GracefulShutdown waits before force killing processes
+const gracefulShutdownTimeout = 3 * time.Second
+
+// shutdownMessage is the countdown line shown while GracefulShutdown waits
+func shutdownMessage(remaining time.Duration) string {
+	return fmt.Sprintf("⏹️  Shutting down... (force kill in %ds)", int(remaining.Seconds()))
+}
+
+// shutdownTickCmd advances the shutdown countdown after a second
+func shutdownTickCmd(remaining time.Duration) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return shutdownTickMsg{remaining: remaining - time.Second}
+	})
+}
+
 // GracefulShutdown stops all running projects immediately
 func (m *DashboardModel) GracefulShutdown() {
 	var wg sync.WaitGroup
@@ -1437,7 +1783,7 @@ func (m *DashboardModel) GracefulShutdown() {
 	select {
 	case <-done:
 		// All processes stopped
-	case <-time.After(3 * time.Second):
+	case <-time.After(gracefulShutdownTimeout):
 		// Timeout - force kill any remaining processes
 		for _, p := range m.projects {
 			if p.Cmd != nil && p.Cmd.Process != nil {
@@ -2,8 +2,13 @@ package ui
 
 import (
 	"bytes"
+	"os/exec"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestNewProject(t *testing.T) {
@@ -99,6 +104,25 @@ func TestProjectURLDetection(t *testing.T) {
 	}
 }
 
+func TestProjectURLTemplate(t *testing.T) {
+	p := NewProject("shop", "/test")
+	p.SetURLTemplate("https://{project}.local:{port}{path}")
+	p.AppendLog("  ➜  Local:   http://localhost:5173/admin/")
+
+	if want := "https://shop.local:5173/admin/"; p.URL != want {
+		t.Errorf("expected URL '%s', got '%s'", want, p.URL)
+	}
+
+	p.SetPort(5174)
+	if want := "https://shop.local:5174/admin/"; p.URL != want {
+		t.Errorf("expected URL '%s' after SetPort, got '%s'", want, p.URL)
+	}
+
+	if got := ExpandURLTemplate("http://{host}:{port}", "shop", "devbox", 3000, ""); got != "http://devbox:3000" {
+		t.Errorf("expected 'http://devbox:3000', got '%s'", got)
+	}
+}
+
 func TestURLPriorityDetection(t *testing.T) {
 	t.Run("Frontend URL overrides backend URL", func(t *testing.T) {
 		p := NewProject("monorepo", "/test")
@@ -351,6 +375,69 @@ FallbackMode:   true,
 	}
 }
 
+func TestDashboardRunnerBroadcast(t *testing.T) {
+	runner := NewDashboardRunner(DashboardConfig{
+		Projects: []*Project{
+			NewProject("web", "/web"),
+			NewProject("api", "/api"),
+		},
+		MaxConcurrency: 4,
+	})
+
+	runner.Broadcast("thermal warning")
+
+	seen := make(map[int]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-runner.GetDashboard().GetUpdateChannel():
+			log, ok := msg.(logMsg)
+			if !ok {
+				t.Fatalf("expected logMsg, got %T", msg)
+			}
+			if log.line != "thermal warning" {
+				t.Errorf("expected line 'thermal warning', got '%s'", log.line)
+			}
+			seen[log.index] = true
+		default:
+			t.Fatalf("expected 2 log messages, got %d", i)
+		}
+	}
+
+	if !seen[0] || !seen[1] {
+		t.Errorf("expected broadcast to reach every project, got %v", seen)
+	}
+}
+
+func TestDashboardShutdownCountdown(t *testing.T) {
+	web := NewProject("web", "/web")
+	api := NewProject("api", "/api")
+	dashboard := NewDashboard([]*Project{web, api}, 4)
+
+	_, cmd := dashboard.Update(shutdownMsg{})
+	if cmd == nil {
+		t.Fatal("expected the countdown to schedule a tick")
+	}
+	for _, p := range []*Project{web, api} {
+		logs := p.GetLogs()
+		if len(logs) != 1 || logs[0] != "⏹️  Shutting down... (force kill in 3s)" {
+			t.Errorf("%s: expected the countdown to start at 3s, got %v", p.Name, logs)
+		}
+	}
+
+	// Each tick logs the remaining time and schedules the next one
+	if _, cmd := dashboard.Update(shutdownTickMsg{remaining: 2 * time.Second}); cmd == nil {
+		t.Error("expected the countdown to keep ticking at 2s")
+	}
+	if logs := api.GetLogs(); logs[len(logs)-1] != "⏹️  Shutting down... (force kill in 2s)" {
+		t.Errorf("expected the countdown to reach 2s, got %v", logs)
+	}
+
+	dashboard.Update(shutdownTickMsg{remaining: 0})
+	if logs := web.GetLogs(); len(logs) != 2 {
+		t.Errorf("expected the countdown to stop at 0s, got %v", logs)
+	}
+}
+
 func TestSimpleRunner(t *testing.T) {
 	runner := NewSimpleRunner()
 
@@ -369,3 +456,186 @@ func TestSimpleRunner(t *testing.T) {
 		t.Errorf("expected StatusRunning, got %s", p.Status)
 	}
 }
+
+func TestRenderHTMLSnapshot(t *testing.T) {
+	p := NewProject("web", "/web")
+	p.SetPhase(PhaseRun)
+	p.SetStatus(StatusRunning)
+	p.SetURL("http://localhost:3000")
+	p.AppendLog("\x1b[32mready\x1b[0m <b>")
+	p.AppendLog("ERR: boom")
+
+	m := NewDashboard([]*Project{p}, 1)
+	out := m.RenderHTMLSnapshot(time.Now())
+
+	checks := []string{
+		"<!DOCTYPE html>",
+		"web",
+		"http://localhost:3000",
+		"<span style=\"color:#0DBC79\">ready</span> &lt;b&gt;", // Process colors are kept
+		"color:#00AAFF;font-weight:bold", // StatusRunning
+		"color:#FF0000\">ERR: boom",       // LogError
+	}
+	for _, want := range checks {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected snapshot to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("expected ANSI escape sequences to be converted to HTML")
+	}
+}
+
+func TestANSIToHTML(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"plain <text>", "plain &lt;text&gt;"},
+		{"\x1b[31merror\x1b[0m done", `<span style="color:#CD3131">error</span> done`},
+		{"\x1b[1;92mok\x1b[22m still green\x1b[39m", `<span style="color:#23D18B;font-weight:bold">ok</span><span style="color:#23D18B"> still green</span>`},
+		{"\x1b[38;5;208mlevel\x1b[m", `<span style="color:#FF8700">level</span>`},
+		{"\x1b[48;2;10;20;30mbg\x1b[0m", `<span style="background:#0A141E">bg</span>`},
+		// Cursor movement and screen clearing are dropped
+		{"\x1b[2K\x1b[1Gvite ready", "vite ready"},
+	}
+	for _, tt := range tests {
+		if got := ansiToHTML(tt.line); got != tt.want {
+			t.Errorf("ansiToHTML(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestDashboardLogFilter(t *testing.T) {
+	p := NewProject("api", "/api")
+	p.AppendLog("GET /health 200")
+	p.AppendLog("ERR: connection refused")
+	p.AppendLog("GET /users 500")
+
+	dashboard := NewDashboard([]*Project{p}, 4)
+	dashboard.compactMode = false
+	dashboard.focusedIndex = 0
+
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !dashboard.filtering {
+		t.Fatal("expected / to open the filter input")
+	}
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("GET")})
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if dashboard.filtering {
+		t.Error("expected enter to close the filter input")
+	}
+	if dashboard.filterMatched != 2 || dashboard.filterTotal != 3 {
+		t.Errorf("expected 2/3 lines to match, got %d/%d", dashboard.filterMatched, dashboard.filterTotal)
+	}
+
+	filter, err := compileLogFilter("level:error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard.filter = filter
+	if got := dashboard.filterLogs(p.GetLogs()); len(got) != 1 {
+		t.Errorf("expected level:error to match 1 line, got %d", len(got))
+	}
+
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if dashboard.filter != nil || dashboard.filterMatched != 3 {
+		t.Errorf("expected ctrl+l to clear the filter, got %v with %d lines", dashboard.filter, dashboard.filterMatched)
+	}
+
+	// ctrl+l only ever clears the filter; clearing logs has its own key
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if logs := p.GetLogs(); len(logs) != 3 {
+		t.Errorf("expected a second ctrl+l to keep the logs, got %v", logs)
+	}
+
+	dashboard.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if logs := p.GetLogs(); len(logs) != 0 {
+		t.Errorf("expected ctrl+k to clear the logs, got %v", logs)
+	}
+}
+
+func TestDashboardLogLimits(t *testing.T) {
+	p := NewProject("api", "/api")
+	p.SetMaxLogLines(3)
+	for _, line := range []string{"one", "two", "three", "four"} {
+		p.AppendLog(line)
+	}
+	if logs := p.GetLogs(); len(logs) != 3 || logs[0] != "two" {
+		t.Errorf("expected the last 3 lines, got %v", logs)
+	}
+
+	web := NewProject("web", "/web")
+	web.AppendLog("0123456789abcdef")
+	web.AppendLog("fedcba9876543210")
+	dashboard := NewDashboard([]*Project{p, web}, 4)
+	dashboard.SetMaxTotalLogBytes(30)
+
+	// 12 bytes in api and 32 in web: web is the largest, so its oldest line goes first
+	if got := p.LogBytes() + web.LogBytes(); got > 30 {
+		t.Errorf("expected logs pruned to 30 bytes, got %d", got)
+	}
+	if logs := web.GetLogs(); len(logs) != 1 || logs[0] != "fedcba9876543210" {
+		t.Errorf("expected web's oldest line to be pruned, got %v", logs)
+	}
+	if len(p.GetLogs()) != 3 {
+		t.Errorf("expected api's logs to be kept, got %v", p.GetLogs())
+	}
+}
+
+func TestDashboardSetTheme(t *testing.T) {
+	m := NewDashboard([]*Project{NewProject("app", "/tmp/app")}, 1)
+
+	if err := m.SetTheme("nord"); err != nil {
+		t.Fatalf("SetTheme(nord): %v", err)
+	}
+	if m.styles != Themes["nord"] {
+		t.Error("SetTheme(nord) did not apply the nord styles")
+	}
+
+	if err := m.SetTheme("bogus"); err == nil {
+		t.Error("SetTheme(bogus) should fail")
+	}
+	if m.styles != Themes["nord"] {
+		t.Error("a failed SetTheme should keep the current styles")
+	}
+
+	if err := m.SetTheme(""); err != nil || m.styles != Themes[DefaultTheme] {
+		t.Errorf("SetTheme(\"\") should select the default theme, err = %v", err)
+	}
+}
+
+func TestProjectRequestRestart(t *testing.T) {
+	p := NewProject("app", "/tmp/app")
+	if p.RequestRestart() {
+		t.Error("RequestRestart should fail without a running command")
+	}
+
+	cmd := exec.Command("sleep", "30")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start sleep: %v", err)
+	}
+	p.SetCmd(cmd)
+	p.SetRunCmd("sleep 30")
+	p.AppendLog("old output")
+
+	if !p.RequestRestart() {
+		t.Fatal("RequestRestart should succeed for a running command")
+	}
+	if err := cmd.Wait(); err == nil {
+		t.Error("expected the process to be stopped")
+	}
+	if !p.TakeRestartRequest() {
+		t.Error("expected a pending restart request")
+	}
+	if p.TakeRestartRequest() {
+		t.Error("TakeRestartRequest should clear the request")
+	}
+
+	p.ClearLogs()
+	if logs := p.GetLogs(); len(logs) != 0 {
+		t.Errorf("expected logs to be cleared, got %v", logs)
+	}
+}
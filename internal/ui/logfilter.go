@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ==========================================
+// Log Filtering (focused view "/" key)
+// ==========================================
+
+// severityFilters are filter shortcuts that select log lines by severity instead of a regex.
+// Lines the app wrote to stderr carry the "ERR: " prefix added by the orchestrator.
+var severityFilters = map[string]*regexp.Regexp{
+	"level:error": regexp.MustCompile(`^ERR: |(?i)\b(?:error|fatal|panic|exception)\b`),
+	"level:warn":  regexp.MustCompile(`^ERR: |(?i)\b(?:error|fatal|panic|exception|warn|warning)\b`),
+}
+
+// newFilterInput creates the text input shown by the Filter key
+func newFilterInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "regex, level:error or level:warn"
+	input.CharLimit = 200
+	return input
+}
+
+// compileLogFilter turns the filter input into a pattern; empty input means no filter
+func compileLogFilter(input string) (*regexp.Regexp, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+	if pattern, ok := severityFilters[strings.ToLower(input)]; ok {
+		return pattern, nil
+	}
+	return regexp.Compile(input)
+}
+
+// openFilter shows the filter input, pre-filled with the active filter
+func (m *DashboardModel) openFilter() tea.Cmd {
+	m.filtering = true
+	m.filterInput.CursorEnd()
+	return m.filterInput.Focus()
+}
+
+// clearFilter removes the active filter and closes the input
+func (m *DashboardModel) clearFilter() {
+	m.filtering = false
+	m.filterInput.Blur()
+	m.filterInput.SetValue("")
+	m.filter = nil
+	m.filterErr = nil
+	m.updateViewportContent()
+}
+
+// updateFilterInput handles keys while the filter input is open.
+// The filter is applied as you type; enter keeps it and closes the input, esc clears it.
+func (m *DashboardModel) updateFilterInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filterInput.Blur()
+		return nil
+	case tea.KeyEsc:
+		m.clearFilter()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+
+	// Keep the last valid filter while a regex is half-typed
+	filter, err := compileLogFilter(m.filterInput.Value())
+	m.filterErr = err
+	if err == nil {
+		m.filter = filter
+		m.updateViewportContent()
+	}
+	return cmd
+}
+
+// filterLogs returns the lines matching the active filter, with the matches highlighted
+func (m *DashboardModel) filterLogs(logs []string) []string {
+	if m.filter == nil {
+		return logs
+	}
+
+	var filtered []string
+	for _, line := range logs {
+		matches := m.filter.FindAllStringIndex(line, -1)
+		if matches == nil {
+			continue
+		}
+		filtered = append(filtered, highlightMatches(line, matches, m.styles.LogMatch))
+	}
+	return filtered
+}
+
+// highlightMatches renders the given byte ranges of line with style
+func highlightMatches(line string, matches [][]int, style lipgloss.Style) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(line[last:match[0]])
+		b.WriteString(style.Render(line[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// renderFilterBar shows the filter input, or the active filter and how many lines it matched.
+// It returns an empty string when no filter is in use.
+func (m *DashboardModel) renderFilterBar() string {
+	var bar string
+	switch {
+	case m.filtering:
+		bar = m.filterInput.View()
+	case m.filter != nil:
+		bar = fmt.Sprintf("🔍 %s %s",
+			m.styles.HelpKey.Render(m.filterInput.Value()),
+			m.styles.HelpDesc.Render(fmt.Sprintf("(%d/%d lines)", m.filterMatched, m.filterTotal)))
+	default:
+		return ""
+	}
+
+	if m.filterErr != nil {
+		bar += "  " + m.styles.LogError.Render("invalid regex")
+	}
+	return bar
+}
@@ -74,10 +74,19 @@ func (m YesNoPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "left", "h", "y", "Y":
+		case "left", "h":
 			m.selected = true
-		case "right", "l", "n", "N":
+		case "right", "l":
 			m.selected = false
+		case "y", "Y":
+			// y and n answer immediately, like most CLI yes/no prompts
+			m.selected = true
+			m.confirmed = true
+			return m, tea.Quit
+		case "n", "N":
+			m.selected = false
+			m.confirmed = true
+			return m, tea.Quit
 		case "tab":
 			m.selected = !m.selected
 		case "enter":
@@ -122,7 +131,7 @@ func (m YesNoPrompt) View() string {
 
 	// Help text
 	b.WriteString("\n")
-	b.WriteString(promptDimStyle.Render("  ← → to select • enter to confirm • esc to cancel"))
+	b.WriteString(promptDimStyle.Render("  y/n to answer • ← → to select • enter to confirm • esc to cancel"))
 
 	return b.String()
 }
@@ -543,6 +552,212 @@ func RunMultiSelectPrompt(title, description string, options []SelectOption) ([]
 	return selected, nil
 }
 
+// ============================================================================
+// Secrets Form (all env vars on one scrollable screen)
+// ============================================================================
+
+// secretsFormFieldHeight is the number of lines each field takes in the form
+const secretsFormFieldHeight = 3
+
+// SecretsFormPrompt lists every env var at once, each with its own text input
+type SecretsFormPrompt struct {
+	title       string
+	description string
+	vars        []EnvVarWithDefault
+	inputs      []textinput.Model
+	focused     int
+	offset      int // index of the first visible field
+	height      int // terminal height, 0 until the first WindowSizeMsg
+	confirmed   bool
+	cancelled   bool
+}
+
+// NewSecretsFormPrompt creates a new secrets form
+func NewSecretsFormPrompt(title, description string, vars []EnvVarWithDefault) *SecretsFormPrompt {
+	inputs := make([]textinput.Model, len(vars))
+	for i, v := range vars {
+		ti := textinput.New()
+		ti.Prompt = "› "
+		ti.Width = 50
+		if isSensitiveEnvVar(v.Name) {
+			// Secrets are masked both in the default hint and while typing
+			ti.EchoMode = textinput.EchoPassword
+			ti.EchoCharacter = '•'
+			ti.Placeholder = maskSecret(v.Default)
+		} else {
+			ti.Placeholder = v.Default
+		}
+		inputs[i] = ti
+	}
+	if len(inputs) > 0 {
+		inputs[0].Focus()
+	}
+
+	return &SecretsFormPrompt{
+		title:       title,
+		description: description,
+		vars:        vars,
+		inputs:      inputs,
+	}
+}
+
+func (m SecretsFormPrompt) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m SecretsFormPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.scrollToFocused()
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "down":
+			return m, m.focus(m.focused + 1)
+		case "shift+tab", "up":
+			return m, m.focus(m.focused - 1)
+		case "enter":
+			m.confirmed = true
+			return m, tea.Quit
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+
+	if len(m.inputs) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+	return m, cmd
+}
+
+// focus moves the cursor to field i, wrapping around at either end
+func (m *SecretsFormPrompt) focus(i int) tea.Cmd {
+	if len(m.inputs) == 0 {
+		return nil
+	}
+	m.inputs[m.focused].Blur()
+	m.focused = (i + len(m.inputs)) % len(m.inputs)
+	m.scrollToFocused()
+	return m.inputs[m.focused].Focus()
+}
+
+// visibleFields returns how many fields fit on screen below the title and above the help text
+func (m SecretsFormPrompt) visibleFields() int {
+	if m.height == 0 {
+		return len(m.inputs)
+	}
+	visible := (m.height - 8) / secretsFormFieldHeight
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
+// scrollToFocused keeps the focused field inside the visible window
+func (m *SecretsFormPrompt) scrollToFocused() {
+	visible := m.visibleFields()
+	if m.focused < m.offset {
+		m.offset = m.focused
+	} else if m.focused >= m.offset+visible {
+		m.offset = m.focused - visible + 1
+	}
+}
+
+func (m SecretsFormPrompt) View() string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(promptTitleStyle.Render("? "+m.title) + "\n")
+
+	// Description
+	if m.description != "" {
+		b.WriteString(promptDimStyle.Render("  "+m.description) + "\n")
+	}
+
+	b.WriteString("\n")
+
+	end := m.offset + m.visibleFields()
+	if end > len(m.inputs) {
+		end = len(m.inputs)
+	}
+
+	if m.offset > 0 {
+		b.WriteString(promptDimStyle.Render(fmt.Sprintf("  ↑ %d more", m.offset)) + "\n")
+	}
+
+	// Fields
+	for i := m.offset; i < end; i++ {
+		v := m.vars[i]
+		cursor := "  "
+		style := promptUnselectedStyle
+		if i == m.focused {
+			cursor = promptCursorStyle.Render("❯ ")
+			style = promptHighlightStyle
+		}
+
+		b.WriteString(cursor + style.Render(v.Name))
+		if v.TargetDir != "" {
+			b.WriteString(promptDimStyle.Render(" (" + v.TargetDir + "/.env)"))
+		}
+		if v.Description != "" {
+			b.WriteString(promptDimStyle.Render(" - " + v.Description))
+		}
+		b.WriteString("\n")
+		b.WriteString("    " + m.inputs[i].View() + "\n\n")
+	}
+
+	if end < len(m.inputs) {
+		b.WriteString(promptDimStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.inputs)-end)) + "\n")
+	}
+
+	// Help text
+	b.WriteString("\n")
+	b.WriteString(promptDimStyle.Render("  tab/↓ next • shift+tab/↑ previous • enter to save all • esc to cancel"))
+
+	return b.String()
+}
+
+// Result returns the entered values and whether the form was submitted.
+// Empty fields fall back to their default; fields with neither are left out.
+func (m SecretsFormPrompt) Result() (map[string]string, bool) {
+	values := make(map[string]string)
+	for i, v := range m.vars {
+		value := strings.TrimSpace(m.inputs[i].Value())
+		if value == "" {
+			value = v.Default
+		}
+		if value != "" {
+			values[v.Name] = value
+		}
+	}
+	return values, m.confirmed && !m.cancelled
+}
+
+// RunSecretsForm runs the secrets form and returns the entered values
+func RunSecretsForm(title, description string, vars []EnvVarWithDefault) (map[string]string, error) {
+	prompt := NewSecretsFormPrompt(title, description, vars)
+	p := tea.NewProgram(prompt)
+
+	model, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.(SecretsFormPrompt)
+	values, confirmed := result.Result()
+
+	if !confirmed {
+		return map[string]string{}, nil
+	}
+
+	return values, nil
+}
+
 // ============================================================================
 // Styled Output Helpers
 // ============================================================================
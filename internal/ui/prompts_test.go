@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestYesNoPromptShortcutsConfirm(t *testing.T) {
+	tests := []struct {
+		key        string
+		defaultYes bool
+		want       bool
+	}{
+		{"y", false, true},
+		{"Y", false, true},
+		{"n", true, false},
+		{"N", true, false},
+	}
+
+	for _, tt := range tests {
+		prompt := NewYesNoPrompt("Continue?", "", tt.defaultYes)
+		model, cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+
+		if cmd == nil {
+			t.Errorf("%q: expected the prompt to quit", tt.key)
+		}
+		selected, confirmed := model.(YesNoPrompt).Result()
+		if !confirmed || selected != tt.want {
+			t.Errorf("%q: Result() = (%v, %v), want (%v, true)", tt.key, selected, confirmed, tt.want)
+		}
+	}
+}
+
+func TestYesNoPromptArrowsOnlySelect(t *testing.T) {
+	prompt := NewYesNoPrompt("Continue?", "", true)
+	model, _ := prompt.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	selected, confirmed := model.(YesNoPrompt).Result()
+	if selected || confirmed {
+		t.Errorf("Result() = (%v, %v), want (false, false) until enter is pressed", selected, confirmed)
+	}
+}
+
+func TestSecretsFormTabAndSubmit(t *testing.T) {
+	vars := []EnvVarWithDefault{
+		{Name: "DATABASE_URL", Default: "postgres://localhost/app"},
+		{Name: "API_KEY"},
+		{Name: "OPTIONAL_FLAG"},
+	}
+	var model tea.Model = *NewSecretsFormPrompt("Secrets", "", vars)
+
+	// Tab past the first field, type into the second and submit everything
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("sk-123")})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatal("expected enter to submit the form")
+	}
+	values, confirmed := model.(SecretsFormPrompt).Result()
+	if !confirmed {
+		t.Fatal("expected the form to be confirmed")
+	}
+	want := map[string]string{
+		"DATABASE_URL": "postgres://localhost/app",
+		"API_KEY":      "sk-123",
+	}
+	if len(values) != len(want) {
+		t.Fatalf("Result() = %v, want %v", values, want)
+	}
+	for name, value := range want {
+		if values[name] != value {
+			t.Errorf("%s = %q, want %q", name, values[name], value)
+		}
+	}
+}
+
+func TestSecretsFormCtrlCAborts(t *testing.T) {
+	vars := []EnvVarWithDefault{{Name: "API_KEY"}, {Name: "DATABASE_URL", Default: "postgres://localhost/app"}}
+	var model tea.Model = *NewSecretsFormPrompt("Secrets", "", vars)
+
+	// Ctrl+C in a masked field aborts the whole form instead of skipping the field
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("sk-123")})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	if cmd == nil {
+		t.Fatal("expected ctrl+c to quit the form")
+	}
+	if _, confirmed := model.(SecretsFormPrompt).Result(); confirmed {
+		t.Error("expected ctrl+c to abort without saving")
+	}
+}
+
+func TestSecretsFormScrollsToFocusedField(t *testing.T) {
+	vars := make([]EnvVarWithDefault, 10)
+	for i := range vars {
+		vars[i] = EnvVarWithDefault{Name: "VAR_" + string(rune('A'+i))}
+	}
+	var model tea.Model = *NewSecretsFormPrompt("Secrets", "", vars)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 80, Height: 14})
+
+	// Shift+tab wraps around to the last field, which must scroll into view
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	view := model.View()
+
+	if !strings.Contains(view, "VAR_J") {
+		t.Errorf("expected the focused last field in view:\n%s", view)
+	}
+	if strings.Contains(view, "VAR_A") {
+		t.Errorf("expected the first field to be scrolled out of view:\n%s", view)
+	}
+}
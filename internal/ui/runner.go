@@ -29,7 +29,14 @@ type DashboardRunner struct {
 type DashboardConfig struct {
 	Projects       []*Project
 	MaxConcurrency int
-	FallbackMode   bool // If true, use simple output instead of TUI
+	FallbackMode   bool   // If true, use simple output instead of TUI
+	ThemeName      string // Color theme (see ThemeNames); empty or unknown selects the default theme
+
+	// MaxLogLines is how many lines each project keeps (0 = DefaultMaxLogLines)
+	MaxLogLines int
+	// MaxTotalLogBytes prunes the oldest lines once all project logs together exceed
+	// this size, for memory-sensitive environments (0 = unlimited)
+	MaxTotalLogBytes int
 }
 
 // NewDashboardRunner creates a new dashboard runner
@@ -42,8 +49,18 @@ func NewDashboardRunner(config DashboardConfig) *DashboardRunner {
 		projects = make([]*Project, 0)
 	}
 
+	if config.MaxLogLines > 0 {
+		for _, p := range projects {
+			p.SetMaxLogLines(config.MaxLogLines)
+		}
+	}
+
 	// Create dashboard model
 	dashboard := NewDashboard(projects, config.MaxConcurrency)
+	dashboard.SetMaxTotalLogBytes(config.MaxTotalLogBytes)
+	if err := dashboard.SetTheme(config.ThemeName); err != nil {
+		dashboard.SetTheme(DefaultTheme)
+	}
 
 	// Create log multiplexer
 	multiplexer := NewLogMultiplexer(projects, dashboard)
@@ -113,6 +130,12 @@ func (dr *DashboardRunner) Stop() {
 	dr.running = false
 	
 	// Gracefully shutdown all running processes
+	// The dashboard counts down to the force kill; fallback output says it once
+	if dr.fallbackMode {
+		dr.Broadcast(shutdownMessage(gracefulShutdownTimeout))
+	} else {
+		dr.dashboard.SendShutdown()
+	}
 	dr.dashboard.GracefulShutdown()
 	
 	dr.cancel()
@@ -187,6 +210,20 @@ func (dr *DashboardRunner) UpdateProject(index int, phase Phase, status Status)
 	dr.dashboard.SendProjectUpdate(index, phase, status)
 }
 
+// Broadcast sends a message to every project's log panel.
+// Use it for global events (thermal throttling, shutdown) that affect all projects.
+func (dr *DashboardRunner) Broadcast(message string) {
+	if dr.fallbackMode {
+		// In fallback mode all projects share stdout, so print once
+		fmt.Println(message)
+		return
+	}
+
+	for i := range dr.dashboard.projects {
+		dr.dashboard.SendLog(i, message)
+	}
+}
+
 // GetWriter returns an io.Writer for a project's logs
 func (dr *DashboardRunner) GetWriter(index int) io.Writer {
 	if dr.fallbackMode {
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ==========================================
+// HTML Snapshots (dashboard "s" key)
+// ==========================================
+
+// snapshotBackground matches the dark terminal the dashboard colors are tuned for
+const snapshotBackground = "#1E1E1E"
+
+// snapshotForeground is the default text color in snapshots
+const snapshotForeground = "#DDDDDD"
+
+// ansiEscapePattern matches terminal escape sequences emitted by child processes
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// ansiPalette holds the 16 standard terminal colors (30-37 and 90-97), as rendered by
+// common dark terminal themes
+var ansiPalette = [16]string{
+	"#000000", "#CD3131", "#0DBC79", "#E5E510", "#2472C8", "#BC3FBC", "#11A8CD", "#E5E5E5",
+	"#666666", "#F14C4C", "#23D18B", "#F5F543", "#3B8EEA", "#D670D6", "#29B8DB", "#FFFFFF",
+}
+
+// SnapshotDir returns the directory snapshots are written to (~/.octo/snapshots)
+func SnapshotDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".octo", "snapshots"), nil
+}
+
+// SaveSnapshot writes the current dashboard state as a self-contained HTML file
+// and returns its path
+func (m *DashboardModel) SaveSnapshot() (string, error) {
+	dir, err := SnapshotDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("octo-%s.html", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(m.RenderHTMLSnapshot(now)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// RenderHTMLSnapshot renders every project's status, URL, timing and logs as HTML.
+// Colors are taken from the dashboard's lipgloss styles so the output matches the terminal.
+func (m *DashboardModel) RenderHTMLSnapshot(now time.Time) string {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>Octo snapshot " + now.Format("2006-01-02 15:04:05") + "</title>\n")
+	b.WriteString("</head>\n")
+	fmt.Fprintf(&b, "<body style=\"margin:0;padding:24px;background:%s;color:%s;font-family:Menlo,Consolas,monospace;font-size:13px\">\n",
+		snapshotBackground, snapshotForeground)
+
+	fmt.Fprintf(&b, "<h1 style=\"%s;font-size:18px;border-bottom:1px solid %s;padding-bottom:8px\">🐙 Octo Dashboard</h1>\n",
+		styleCSS(m.styles.Header), styleColor(m.styles.Footer.GetForeground()))
+	fmt.Fprintf(&b, "<p style=\"%s\">Captured %s</p>\n", styleCSS(m.styles.Help), now.Format("2006-01-02 15:04:05 MST"))
+
+	for _, p := range m.projects {
+		p.mu.RLock()
+		name, phase, status := p.Name, p.Phase, p.Status
+		url, port, startTime := p.URL, p.Port, p.StartTime
+		p.mu.RUnlock()
+		if url == "" && port > 0 {
+			url = fmt.Sprintf("http://localhost:%d", port)
+		}
+
+		phaseStyle, phaseIcon := m.phaseAppearance(phase)
+		statusStyle, statusIcon := m.statusAppearance(status)
+
+		fmt.Fprintf(&b, "<section style=\"border:1px solid %s;border-radius:6px;padding:12px;margin:16px 0\">\n",
+			styleColor(m.styles.ProjectList.GetBorderTopForeground()))
+		fmt.Fprintf(&b, "<h2 style=\"font-size:15px;margin:0 0 8px 0\">%s</h2>\n", html.EscapeString(name))
+
+		b.WriteString("<div>")
+		fmt.Fprintf(&b, "<span style=\"%s\">%s %s</span> &nbsp; ", styleCSS(phaseStyle), phaseIcon, html.EscapeString(string(phase)))
+		fmt.Fprintf(&b, "<span style=\"%s\">%s %s</span>", styleCSS(statusStyle), statusIcon, html.EscapeString(string(status)))
+		if !startTime.IsZero() {
+			fmt.Fprintf(&b, " &nbsp; <span style=\"%s\">⏱ %s</span>", styleCSS(m.styles.HelpDesc), now.Sub(startTime).Round(time.Second))
+		}
+		if url != "" {
+			fmt.Fprintf(&b, " &nbsp; <a href=\"%s\" style=\"%s\">%s</a>",
+				html.EscapeString(url), styleCSS(m.styles.HelpKey), html.EscapeString(url))
+		}
+		b.WriteString("</div>\n")
+
+		fmt.Fprintf(&b, "<pre style=\"border:1px solid %s;border-radius:6px;padding:8px;margin:8px 0 0 0;white-space:pre-wrap;overflow-x:auto\">",
+			styleColor(m.styles.LogViewport.GetBorderTopForeground()))
+		for _, line := range p.GetLogs() {
+			// Colors the process printed are kept; only the prefix check ignores them
+			style := m.styles.LogLine
+			if strings.HasPrefix(ansiEscapePattern.ReplaceAllString(line, ""), "ERR: ") {
+				style = m.styles.LogError
+			}
+			fmt.Fprintf(&b, "<span style=\"%s\">%s</span>\n", styleCSS(style), ansiToHTML(line))
+		}
+		b.WriteString("</pre>\n</section>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// broadcastLog appends a notice to every project's logs and refreshes the visible viewport
+func (m *DashboardModel) broadcastLog(line string) {
+	for _, p := range m.projects {
+		p.AppendLog(line)
+	}
+	if m.focusedIndex >= 0 {
+		m.updateViewportContent()
+	}
+	if m.compactMode {
+		m.updateCompactViewportContent()
+	}
+}
+
+// ansiState is the text styling selected by SGR escape sequences so far
+type ansiState struct {
+	fg, bg                  string
+	bold, italic, underline bool
+}
+
+// css returns the inline CSS for the state, or "" for the default styling
+func (s ansiState) css() string {
+	var props []string
+	if s.fg != "" {
+		props = append(props, "color:"+s.fg)
+	}
+	if s.bg != "" {
+		props = append(props, "background:"+s.bg)
+	}
+	if s.bold {
+		props = append(props, "font-weight:bold")
+	}
+	if s.italic {
+		props = append(props, "font-style:italic")
+	}
+	if s.underline {
+		props = append(props, "text-decoration:underline")
+	}
+	return strings.Join(props, ";")
+}
+
+// apply updates the state with the parameters of one SGR sequence (e.g. "1;32")
+func (s *ansiState) apply(params string) {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			code = 0 // An empty parameter means reset
+		}
+		switch {
+		case code == 0:
+			*s = ansiState{}
+		case code == 1:
+			s.bold = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold = false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			s.fg = ansiPalette[code-90+8]
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			s.bg = ansiPalette[code-100+8]
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			color, used := ansiExtendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// ansiExtendedColor parses the parameters after 38 or 48: "5;n" selects one of 256 colors
+// and "2;r;g;b" a true color. It returns the CSS color and how many parameters it used.
+func ansiExtendedColor(params []string) (string, int) {
+	if len(params) >= 2 && params[0] == "5" {
+		n, err := strconv.Atoi(params[1])
+		if err != nil || n < 0 || n > 255 {
+			return "", 2
+		}
+		switch {
+		case n < 16:
+			return ansiPalette[n], 2
+		case n < 232:
+			// 6x6x6 color cube
+			n -= 16
+			level := func(v int) int {
+				if v == 0 {
+					return 0
+				}
+				return 55 + v*40
+			}
+			return fmt.Sprintf("#%02X%02X%02X", level(n/36), level(n/6%6), level(n%6)), 2
+		default:
+			gray := 8 + (n-232)*10
+			return fmt.Sprintf("#%02X%02X%02X", gray, gray, gray), 2
+		}
+	}
+	if len(params) >= 4 && params[0] == "2" {
+		var rgb [3]int
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(params[i+1])
+		}
+		return fmt.Sprintf("#%02X%02X%02X", rgb[0]&0xFF, rgb[1]&0xFF, rgb[2]&0xFF), 4
+	}
+	return "", len(params)
+}
+
+// ansiToHTML escapes a log line for HTML, turning its SGR color sequences into styled spans.
+// Other escape sequences (cursor movement, clearing) are dropped.
+func ansiToHTML(line string) string {
+	var b strings.Builder
+	var state ansiState
+	write := func(text string) {
+		if text == "" {
+			return
+		}
+		if css := state.css(); css != "" {
+			fmt.Fprintf(&b, "<span style=\"%s\">%s</span>", css, html.EscapeString(text))
+		} else {
+			b.WriteString(html.EscapeString(text))
+		}
+	}
+
+	last := 0
+	for _, match := range ansiEscapePattern.FindAllStringIndex(line, -1) {
+		write(line[last:match[0]])
+		if seq := line[match[0]:match[1]]; strings.HasSuffix(seq, "m") {
+			state.apply(seq[2 : len(seq)-1])
+		}
+		last = match[1]
+	}
+	write(line[last:])
+	return b.String()
+}
+
+// styleCSS converts the text attributes of a lipgloss style to inline CSS
+func styleCSS(style lipgloss.Style) string {
+	var props []string
+	if color := styleColor(style.GetForeground()); color != "" {
+		props = append(props, "color:"+color)
+	}
+	if color := styleColor(style.GetBackground()); color != "" {
+		props = append(props, "background:"+color)
+	}
+	if style.GetBold() {
+		props = append(props, "font-weight:bold")
+	}
+	if style.GetItalic() {
+		props = append(props, "font-style:italic")
+	}
+	if style.GetUnderline() {
+		props = append(props, "text-decoration:underline")
+	}
+	return strings.Join(props, ";")
+}
+
+// styleColor returns the CSS color for a lipgloss color, using the dark variant of adaptive colors
+func styleColor(color lipgloss.TerminalColor) string {
+	switch c := color.(type) {
+	case lipgloss.Color:
+		return string(c)
+	case lipgloss.AdaptiveColor:
+		return c.Dark
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ==========================================
+// Dashboard Themes (--theme)
+// ==========================================
+
+// DefaultTheme is the theme used when none is configured
+const DefaultTheme = "default"
+
+// palette holds the colors a theme assigns to the dashboard styles
+type palette struct {
+	subtle     lipgloss.TerminalColor // Borders, pending items and help text
+	highlight  lipgloss.TerminalColor // Header, focused project and log border
+	success    lipgloss.TerminalColor
+	warning    lipgloss.TerminalColor
+	errorColor lipgloss.TerminalColor
+	info       lipgloss.TerminalColor // Running status and run phase
+	setup      lipgloss.TerminalColor // Setup phase
+	build      lipgloss.TerminalColor // Build phase
+	selected   lipgloss.TerminalColor // Background of the selected project
+}
+
+// defaultPalette is the original purple/green color scheme
+var defaultPalette = palette{
+	subtle:     lipgloss.AdaptiveColor{Light: "#666", Dark: "#999"},
+	highlight:  lipgloss.AdaptiveColor{Light: "#7D56F4", Dark: "#AD8EE6"},
+	success:    lipgloss.AdaptiveColor{Light: "#00AA00", Dark: "#00FF00"},
+	warning:    lipgloss.AdaptiveColor{Light: "#AAAA00", Dark: "#FFFF00"},
+	errorColor: lipgloss.AdaptiveColor{Light: "#AA0000", Dark: "#FF0000"},
+	info:       lipgloss.AdaptiveColor{Light: "#0066CC", Dark: "#00AAFF"},
+	setup:      lipgloss.AdaptiveColor{Light: "#9933FF", Dark: "#CC99FF"},
+	build:      lipgloss.AdaptiveColor{Light: "#FF9900", Dark: "#FFCC00"},
+	selected:   lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#333333"},
+}
+
+// solarizedPalette uses Ethan Schoonover's Solarized accent colors
+var solarizedPalette = palette{
+	subtle:     lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586E75"},
+	highlight:  lipgloss.Color("#268BD2"),
+	success:    lipgloss.Color("#859900"),
+	warning:    lipgloss.Color("#B58900"),
+	errorColor: lipgloss.Color("#DC322F"),
+	info:       lipgloss.Color("#2AA198"),
+	setup:      lipgloss.Color("#6C71C4"),
+	build:      lipgloss.Color("#CB4B16"),
+	selected:   lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+}
+
+// nordPalette uses the Nord Frost and Aurora colors
+var nordPalette = palette{
+	subtle:     lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#616E88"},
+	highlight:  lipgloss.Color("#88C0D0"),
+	success:    lipgloss.Color("#A3BE8C"),
+	warning:    lipgloss.Color("#EBCB8B"),
+	errorColor: lipgloss.Color("#BF616A"),
+	info:       lipgloss.Color("#81A1C1"),
+	setup:      lipgloss.Color("#B48EAD"),
+	build:      lipgloss.Color("#D08770"),
+	selected:   lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#3B4252"},
+}
+
+// Themes maps each theme name to its dashboard styles
+var Themes = map[string]*Styles{
+	DefaultTheme: newStyles(defaultPalette),
+	"solarized":  newStyles(solarizedPalette),
+	"nord":       newStyles(nordPalette),
+}
+
+// ThemeNames returns the available theme names in alphabetical order
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTheme switches the dashboard to the named theme
+func (m *DashboardModel) SetTheme(name string) error {
+	styles, err := ThemeStyles(name)
+	if err != nil {
+		return err
+	}
+	m.styles = styles
+	return nil
+}
+
+// ThemeStyles returns the styles of the named theme; an empty name selects the default theme
+func ThemeStyles(name string) (*Styles, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	styles, ok := Themes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	return styles, nil
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/harshul/octo-cli/internal/analyzer"
 	"github.com/harshul/octo-cli/internal/doctor"
 )
@@ -188,6 +189,10 @@ func PromptForRuntimeInstall(runtimeName string) {
 		fmt.Println("   • macOS: brew install openjdk")
 		fmt.Println("   • Ubuntu: sudo apt install openjdk-17-jdk")
 		fmt.Println("   • Or visit: https://adoptium.net/")
+	case "Kotlin":
+		fmt.Println("   • macOS: brew install kotlin")
+		fmt.Println("   • All platforms: sdk install kotlin (via SDKMAN!)")
+		fmt.Println("   • Or visit: https://kotlinlang.org/docs/command-line.html")
 	case "Go":
 		fmt.Println("   • macOS: brew install go")
 		fmt.Println("   • Ubuntu: sudo apt install golang")
@@ -199,6 +204,10 @@ func PromptForRuntimeInstall(runtimeName string) {
 	case "Rust":
 		fmt.Println("   • All platforms: curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh")
 		fmt.Println("   • Or visit: https://www.rust-lang.org/")
+	case "Swift":
+		fmt.Println("   • macOS: xcode-select --install")
+		fmt.Println("   • Linux: https://www.swift.org/install/linux/")
+		fmt.Println("   • Or visit: https://www.swift.org/")
 	}
 	fmt.Println()
 }
@@ -241,6 +250,21 @@ func DisplayMissingSecrets(missing []string, descriptions map[string]string) {
 // PromptForSecrets asks the user to enter values for missing secrets
 // Returns a map of variable names to their values
 func PromptForSecrets(missing []string, descriptions map[string]string) map[string]string {
+	if term.IsTerminal(os.Stdin.Fd()) {
+		vars := make([]EnvVarWithDefault, len(missing))
+		for i, name := range missing {
+			vars[i] = EnvVarWithDefault{Name: name, Description: descriptions[name]}
+		}
+		values, err := RunSecretsForm("Secret Onboarding", "This app needs some environment variables. Leave a field empty to skip it.", vars)
+		if err == nil {
+			return values
+		}
+	}
+	return promptForSecretsPlain(missing, descriptions)
+}
+
+// promptForSecretsPlain asks for each secret in turn, for when stdin is not a terminal
+func promptForSecretsPlain(missing []string, descriptions map[string]string) map[string]string {
 	values := make(map[string]string)
 	reader := bufio.NewReader(os.Stdin)
 
@@ -327,6 +351,17 @@ type EnvVarWithDefault struct {
 // PromptForSecretsWithDefaults prompts for secrets with README-sourced defaults
 // Returns a map of variable names to their values
 func PromptForSecretsWithDefaults(vars []EnvVarWithDefault) map[string]string {
+	if term.IsTerminal(os.Stdin.Fd()) {
+		values, err := RunSecretsForm("Smart Secret Onboarding", "Values from your README and code are suggested - leave a field empty to accept them.", vars)
+		if err == nil {
+			return values
+		}
+	}
+	return promptForSecretsWithDefaultsPlain(vars)
+}
+
+// promptForSecretsWithDefaultsPlain prompts for each secret in turn, for when stdin is not a terminal
+func promptForSecretsWithDefaultsPlain(vars []EnvVarWithDefault) map[string]string {
 	values := make(map[string]string)
 	reader := bufio.NewReader(os.Stdin)
 
@@ -362,16 +397,26 @@ func PromptForSecretsWithDefaults(vars []EnvVarWithDefault) map[string]string {
 				fmt.Printf("   📝 %s\n", v.Description)
 			}
 
+			// Secret defaults are masked in the hint
+			sensitive := isSensitiveEnvVar(v.Name)
+
 			// Show the prompt
 			if v.Default != "" {
-				fmt.Printf("   %s [%s]: ", v.Name, v.Default)
+				hint := v.Default
+				if sensitive {
+					hint = maskSecret(v.Default)
+				}
+				fmt.Printf("   %s [%s]: ", v.Name, hint)
 			} else {
 				fmt.Printf("   %s: ", v.Name)
 			}
 
+			// Stdin is not a terminal here (the form masks secrets), so there is no echo to hide
 			value, err := reader.ReadString('\n')
 			if err != nil {
-				continue
+				// Input ended: stop prompting and keep what was entered so far
+				fmt.Println()
+				return values
 			}
 
 			value = strings.TrimSpace(value)
@@ -470,4 +515,18 @@ func maskSecret(value string) string {
 	
 	// For secrets, show first 3 and last 3 chars
 	return value[:3] + strings.Repeat("*", len(value)-6) + value[len(value)-3:]
-}
\ No newline at end of file
+}
+
+// sensitiveEnvVarMarkers identify variables whose values should not be echoed
+var sensitiveEnvVarMarkers = []string{"KEY", "SECRET", "TOKEN", "PASSWORD", "CERT"}
+
+// isSensitiveEnvVar checks if an env var name suggests a secret value
+func isSensitiveEnvVar(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range sensitiveEnvVarMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultInterval is how often the file tree is polled when native file
+// notifications are unavailable (e.g. the inotify watch limit is exhausted)
+const DefaultInterval = 500 * time.Millisecond
+
+// alwaysIgnored are directories that are never worth watching
+var alwaysIgnored = []string{".git"}
+
+// fileState is the last observed state of a watched file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher reports file changes under a set of paths.
+// It uses the platform's file notifications through fsnotify and falls back
+// to polling when they cannot be set up.
+type Watcher struct {
+	root     string
+	paths    []string
+	ignore   []string
+	interval time.Duration
+	snapshot map[string]fileState
+}
+
+// New creates a watcher rooted at root.
+// paths are relative to root (empty = watch the whole root); ignore entries
+// match either a directory/file base name or a path relative to root.
+func New(root string, paths []string, ignore []string) *Watcher {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	return &Watcher{
+		root:     root,
+		paths:    paths,
+		ignore:   append(append([]string{}, alwaysIgnored...), ignore...),
+		interval: DefaultInterval,
+	}
+}
+
+// Watch starts watching in the background and returns a channel that receives
+// the relative path of a changed file. A change that arrives while another is
+// still pending is dropped, so bursts are reported once. The channel is closed
+// when ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context) <-chan string {
+	changes := make(chan string, 1)
+
+	fsw, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = w.addTree(fsw)
+		if err != nil {
+			fsw.Close()
+		}
+	}
+	if err != nil {
+		w.poll(ctx, changes)
+		return changes
+	}
+
+	go func() {
+		defer close(changes)
+		defer fsw.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-fsw.Events:
+				if !ok {
+					return
+				}
+				w.handleEvent(fsw, event, changes)
+			case _, ok := <-fsw.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return changes
+}
+
+// handleEvent forwards a notification for a non-ignored path and starts
+// watching directories created after Watch was called
+func (w *Watcher) handleEvent(fsw *fsnotify.Watcher, event fsnotify.Event, changes chan<- string) {
+	if event.Op == fsnotify.Chmod {
+		return
+	}
+
+	rel, err := filepath.Rel(w.root, event.Name)
+	if err != nil {
+		rel = event.Name
+	}
+	if w.isIgnored(rel, filepath.Base(event.Name)) {
+		return
+	}
+
+	if event.Op.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.addDir(fsw, event.Name)
+		}
+	}
+
+	select {
+	case changes <- rel:
+	default:
+		// A change is already pending; the consumer will restart anyway
+	}
+}
+
+// addTree registers every non-ignored directory under the watched paths.
+// fsnotify is not recursive, so each directory is added on its own.
+func (w *Watcher) addTree(fsw *fsnotify.Watcher) error {
+	for _, p := range w.paths {
+		start := filepath.Join(w.root, p)
+		info, err := os.Stat(start)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			if err := fsw.Add(start); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.addDir(fsw, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDir watches dir and every non-ignored directory below it
+func (w *Watcher) addDir(fsw *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(w.root, path)
+		if relErr != nil {
+			rel = path
+		}
+		if rel != "." && w.isIgnored(rel, info.Name()) {
+			return filepath.SkipDir
+		}
+		return fsw.Add(path)
+	})
+}
+
+// poll compares snapshots of the file tree every interval and reports changes on changes
+func (w *Watcher) poll(ctx context.Context, changes chan string) {
+	w.snapshot = w.scan()
+
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				current := w.scan()
+				changed := diff(w.snapshot, current)
+				w.snapshot = current
+				if changed == "" {
+					continue
+				}
+				select {
+				case changes <- changed:
+				default:
+					// A change is already pending; the consumer will restart anyway
+				}
+			}
+		}
+	}()
+}
+
+// scan walks all watched paths and records the state of every file
+func (w *Watcher) scan() map[string]fileState {
+	state := make(map[string]fileState)
+
+	for _, p := range w.paths {
+		start := filepath.Join(w.root, p)
+		filepath.Walk(start, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			rel, relErr := filepath.Rel(w.root, path)
+			if relErr != nil {
+				rel = path
+			}
+
+			if w.isIgnored(rel, info.Name()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !info.IsDir() {
+				state[rel] = fileState{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+
+	return state
+}
+
+// isIgnored checks a path against the ignore list
+func (w *Watcher) isIgnored(rel string, name string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range w.ignore {
+		pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+		if pattern == "" {
+			continue
+		}
+		if name == pattern || rel == pattern || strings.HasPrefix(rel, pattern+"/") {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// diff returns the first path that was added, removed or modified between two snapshots
+func diff(before, after map[string]fileState) string {
+	for path, state := range after {
+		prev, ok := before[path]
+		if !ok || !prev.modTime.Equal(state.modTime) || prev.size != state.size {
+			return path
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitForChange returns the next reported path, or "" if nothing arrives in time
+func waitForChange(changes <-chan string, timeout time.Duration) string {
+	select {
+	case path := <-changes:
+		return path
+	case <-time.After(timeout):
+		return ""
+	}
+}
+
+// waitFor reports whether want is reported in time; one write can produce several events
+func waitFor(changes <-chan string, want string, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case path := <-changes:
+			if path == want {
+				return true
+			}
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchReportsChanges(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "src", "main.go"), "package main")
+	writeFile(t, filepath.Join(root, "node_modules", "dep", "index.js"), "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := New(root, nil, []string{"node_modules"}).Watch(ctx)
+
+	// Changes inside ignored directories are not reported
+	writeFile(t, filepath.Join(root, "node_modules", "dep", "index.js"), "changed")
+	if got := waitForChange(changes, 300*time.Millisecond); got != "" {
+		t.Errorf("expected no change for an ignored directory, got %q", got)
+	}
+
+	writeFile(t, filepath.Join(root, "src", "main.go"), "package main // changed")
+	if !waitFor(changes, filepath.Join("src", "main.go"), 2*time.Second) {
+		t.Error("expected src/main.go to be reported")
+	}
+
+	// Directories created after Watch started are watched too
+	if err := os.Mkdir(filepath.Join(root, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !waitFor(changes, "pkg", 2*time.Second) {
+		t.Fatal("expected the new pkg directory to be reported")
+	}
+	writeFile(t, filepath.Join(root, "pkg", "util.go"), "package pkg")
+	if !waitFor(changes, filepath.Join("pkg", "util.go"), 2*time.Second) {
+		t.Error("expected pkg/util.go to be reported")
+	}
+
+	cancel()
+	for range changes {
+	}
+}
+
+func TestPollFallback(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "app.py"), "print(1)")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := New(root, nil, nil)
+	w.interval = 20 * time.Millisecond
+	changes := make(chan string, 1)
+	w.poll(ctx, changes)
+
+	writeFile(t, filepath.Join(root, "app.py"), "print(2) # longer")
+	if !waitFor(changes, "app.py", 2*time.Second) {
+		t.Error("expected app.py to be reported")
+	}
+}
+
+func TestIsIgnored(t *testing.T) {
+	w := New("/project", nil, []string{"node_modules", "build/", "docs/generated", "*.log"})
+
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{".git", true},
+		{"node_modules", true},
+		{"packages/web/node_modules", true},
+		{"build/out.js", true},
+		{"docs/generated/api.md", true},
+		{"docs/guide.md", false},
+		{"server.log", true},
+		{"src/main.go", false},
+	}
+	for _, tt := range tests {
+		if got := w.isIgnored(tt.rel, filepath.Base(tt.rel)); got != tt.want {
+			t.Errorf("isIgnored(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}